@@ -82,6 +82,24 @@ teardown() {
 	assert_line --index 0 --partial 'Release 0.0.2'
 }
 
+@test "vrsn bump w. git tags: --tag-name and --tag-msg with commits" {
+	git checkout -b "$test_branch"
+	echo "update" >>README.md
+	git add README.md
+	git commit -m "update readme"
+
+	run vrsn bump patch --git-tag --tag-name='v{{.Version}}' \
+		--tag-msg='{{.BumpType | upper}} {{.Previous}} on {{.Branch}}: {{join ", " .Commits}}'
+	assert_success
+
+	new=$(git --no-pager tag --list --points-at HEAD)
+	assert_equal "v0.0.2" "$new"
+
+	run git --no-pager tag --list --points-at HEAD -n1
+	assert_success
+	assert_line --index 0 --partial "PATCH 0.0.1 on $test_branch: update readme"
+}
+
 @test "vrsn bump w. git tags: in config file" {
 	git checkout -b "$test_branch"
 	echo "update" >>README.md
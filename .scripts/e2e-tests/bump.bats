@@ -62,7 +62,7 @@ teardown() {
 	git checkout -b "$test_branch"
 	run vrsn bump fail
 	assert_failure
-	assert_line --index 0 'invalid argument "fail" for "vrsn bump"'
+	assert_line --index 0 'invalid usage: invalid argument "fail" for "vrsn bump"'
 
 	new=$(head -n1 VERSION)
 	assert_equal "0.0.1" "$new"
@@ -139,6 +139,23 @@ teardown() {
 	rm "$file"
 }
 
+@test "vrsn bump w. pubspec.yaml: --pubspec-build-number uses the strategy" {
+	git checkout -b "$test_branch"
+	file='pubspec.yaml'
+	printf 'name: app\nversion: 1.2.3+41\n' >"$file"
+	run vrsn bump minor --file="$file" --pubspec-build-number --build-number-strategy increment
+	assert_success
+	assert_line --index 0 'version bumped from 1.2.3 to 1.3.0'
+
+	run vrsn get --file="$file" --build-number
+	assert_success
+	assert_output '42'
+
+	run vrsn get --file="$file"
+	assert_output '1.3.0'
+	rm "$file"
+}
+
 @test "vrsn bump w. VERSION file: --commit default commit message" {
 	git checkout -b "$test_branch"
 	run vrsn bump minor --commit
@@ -210,3 +227,30 @@ teardown() {
 	assert_line --index 0 --partial "$commit_msg"
 	git reset "$(git rev-parse HEAD^1)"
 }
+
+@test "vrsn bump w. VERSION file: hooks run and their files are committed" {
+	git checkout -b "$test_branch"
+
+	run vrsn bump minor --config="$BATS_TEST_DIRNAME/hooks.toml"
+	assert_success
+	assert_line --index 0 'pre-bump 0.0.1 to 0.1.0'
+	assert_line --index 1 'version bumped from 0.0.1 to 0.1.0'
+
+	run git --no-pager show --name-only --format= HEAD
+	assert_line 'VERSION'
+	assert_line 'version.lock'
+
+	git reset "$(git rev-parse HEAD^1)"
+}
+
+@test "vrsn bump w. VERSION file: failing hook restores version file" {
+	git checkout -b "$test_branch"
+	printf "[hooks]\npost-write = ['false']\n" >"$BATS_TMPDIR/failing-hook.toml"
+
+	run vrsn bump minor --config="$BATS_TMPDIR/failing-hook.toml"
+	assert_failure 14
+	assert_output --partial 'hook command failed: post-write hook "false"'
+
+	new=$(head -n1 VERSION)
+	assert_equal "0.0.1" "$new"
+}
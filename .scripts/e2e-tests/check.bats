@@ -36,7 +36,7 @@ teardown() {
 @test "vrsn check w. VERSION file: no bump" {
 	git checkout -b "$test_branch"
 	run vrsn check
-	assert_failure
+	assert_failure 3
 	assert_line --index 0 'was: 0.0.1'
 	assert_line --index 1 'now: 0.0.1'
 	assert_line --index 2 --partial 'version has not been bumped'
@@ -56,7 +56,7 @@ teardown() {
 	git checkout -b "$test_branch"
 	echo "0.2.0" >VERSION
 	run vrsn check
-	assert_failure
+	assert_failure 4
 	assert_line --index 0 'was: 0.0.1'
 	assert_line --index 1 'now: 0.2.0'
 	assert_line --index 2 --partial 'invalid version bump'
@@ -117,3 +117,30 @@ teardown() {
 
 	rm "$file"
 }
+
+@test "vrsn check w. --max-version: rejects a bump past the max" {
+	run vrsn check --was 1.9.0 --now 2.0.0 --max-version 1.x
+	assert_failure 12
+	assert_line --index 2 --partial '2.0.0 is not in <=1.x'
+}
+
+@test "vrsn check w. --range: accepts a bump in the range" {
+	run vrsn check --was 1.9.0 --now 1.10.0 --range '^1.2 || ~2.0'
+	assert_success
+	assert_line --index 2 'valid version bump'
+}
+
+@test "vrsn check w. branch policy: rejects a bump the rule doesn't allow" {
+	git checkout -b "$test_branch"
+	cfg_file="$BATS_TEST_DIRNAME/policy.toml"
+	run vrsn check --config="$cfg_file" --was 1.2.3 --now 1.3.0
+	assert_failure 13
+	assert_line --index 2 --partial "rule bats-* for branch $test_branch allows patch bumps, not minor"
+}
+
+@test "vrsn check w. branch policy: accepts a bump the rule allows" {
+	git checkout -b "$test_branch"
+	cfg_file="$BATS_TEST_DIRNAME/policy.toml"
+	run vrsn check --config="$cfg_file" --was 1.2.3 --now 1.2.4
+	assert_success
+}
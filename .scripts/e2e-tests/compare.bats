@@ -0,0 +1,49 @@
+#!/usr/bin/env bats
+
+# e2e tests for the `vrsn compare`, `vrsn sort` and `vrsn satisfies` commands
+
+setup() {
+	echo "### test setup ###"
+	bats_load_library bats-support
+	bats_load_library bats-assert
+}
+
+@test "vrsn compare: prints lt, eq or gt" {
+	run vrsn compare 0.0.9 0.0.10
+	assert_success
+	assert_output 'lt'
+
+	run vrsn compare v1.2.3 1.2.3
+	assert_success
+	assert_output 'eq'
+
+	run vrsn compare 2.0.0 1.9.9
+	assert_success
+	assert_output 'gt'
+}
+
+@test "vrsn sort: sorts stdin by precedence" {
+	run bash -c "printf '1.10.0\n0.0.10\n\n0.0.9\n' | vrsn sort"
+	assert_success
+	assert_line --index 0 '0.0.9'
+	assert_line --index 1 '0.0.10'
+	assert_line --index 2 '1.10.0'
+}
+
+@test "vrsn sort: --reverse sorts highest first" {
+	run bash -c "printf '0.0.9\n1.10.0\n' | vrsn sort --reverse"
+	assert_success
+	assert_line --index 0 '1.10.0'
+	assert_line --index 1 '0.0.9'
+}
+
+@test "vrsn satisfies: succeeds when the version is in range" {
+	run vrsn satisfies 1.4.2 '^1.2 || ~2.0'
+	assert_success
+}
+
+@test "vrsn satisfies: fails when the version is out of range" {
+	run vrsn satisfies 2.1.0 '^1.2 || ~2.0'
+	assert_failure 12
+	assert_output --partial 'version does not satisfy constraint'
+}
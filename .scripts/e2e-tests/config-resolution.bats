@@ -128,3 +128,74 @@ teardown() {
 	rm vrsn.toml
 	git reset "$(git rev-parse HEAD^1)"
 }
+
+@test "vrsn config resolution: project config merges with global config" {
+	git checkout -b "$test_branch"
+	printf "[bump]\ngit-tag = false\n" >vrsn.toml
+
+	run env XDG_CONFIG_DIR="$xdg_dir" vrsn bump patch
+	assert_success
+	assert_line --index 1 'version file committed'
+
+	run git --no-pager log --oneline -n 1
+	assert_line --index 0 --partial 'global config commit'
+
+	rm vrsn.toml
+	git reset "$(git rev-parse HEAD^1)"
+}
+
+@test "vrsn config resolution: package config overrides repository root config" {
+	git checkout -b "$test_branch"
+	write-config vrsn.toml 'root config commit'
+	mkdir -p packages/api
+	printf "[bump]\ncommit-msg = 'package config commit'\n" >packages/api/vrsn.toml
+
+	cd packages/api || exit 1
+	run vrsn config show --origin
+	assert_success
+	assert_line --regexp "bump.commit = true +../../vrsn.toml"
+	assert_line --regexp "bump.commit-msg = \"package config commit\" +vrsn.toml"
+	assert_line --regexp "check.max-version = \"\" +default"
+
+	cd "$test_dir" || exit 1
+	rm -rf vrsn.toml packages
+}
+
+@test "vrsn config resolution: VRSN_* environment variables override config files" {
+	git checkout -b "$test_branch"
+	write-config vrsn.toml 'project config commit'
+
+	run env VRSN_BUMP_COMMIT_MSG='env commit' vrsn bump patch
+	assert_success
+
+	run git --no-pager log --oneline -n 1
+	assert_line --index 0 --partial 'env commit'
+
+	rm vrsn.toml
+	git reset "$(git rev-parse HEAD^1)"
+}
+
+@test "vrsn config validate: reports unknown keys with their position" {
+	printf "[bump]\ncomit = true\n" >vrsn.toml
+
+	run vrsn config validate
+	assert_failure 11
+	assert_line --index 0 'vrsn.toml:2:1: unknown key bump.comit'
+
+	rm vrsn.toml
+}
+
+@test "vrsn config init: writes config with the detected version files" {
+	run vrsn config init --yes --commit
+	assert_success
+	assert_line --index 0 'created vrsn.toml'
+
+	run vrsn config validate
+	assert_success
+
+	run vrsn config show --origin
+	assert_line --regexp 'files = \["VERSION"\] +vrsn.toml'
+	assert_line --regexp 'bump.commit = true +vrsn.toml'
+
+	rm vrsn.toml
+}
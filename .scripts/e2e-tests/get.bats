@@ -61,7 +61,7 @@ teardown() {
 	printf '{"version":"1.2.3"}' >package.json
 
 	run vrsn get
-	assert_failure
+	assert_failure 6
 	assert_output --partial 'multiple version files found in directory'
 }
 
@@ -90,7 +90,7 @@ teardown() {
 	cd empty-dir || exit 1
 
 	run vrsn get
-	assert_failure
+	assert_failure 7
 	assert_output --partial 'no version files found in directory'
 
 	cd "$test_dir" || exit 1
@@ -115,6 +115,70 @@ teardown() {
 
 	cfg_file="$BATS_TEST_DIRNAME/multi-file.toml"
 	run vrsn check --config="$cfg_file"
-	assert_failure
+	assert_failure 5
 	assert_output --partial 'version files do not contain matching versions'
 }
+
+@test "vrsn check w. files in config: --consistency on the base branch" {
+	printf '{"version":"0.0.1"}' >package.json
+
+	cfg_file="$BATS_TEST_DIRNAME/multi-file.toml"
+	run vrsn check --consistency --config="$cfg_file"
+	assert_success
+	assert_line --partial 'package.json'
+	assert_line 'all version sources match'
+}
+
+@test "vrsn check w. files in config: --consistency names the outliers" {
+	git checkout -b "$test_branch"
+	printf '{"version":"0.2.0"}' >package.json
+	git tag -a "0.0.1" -m "Release 0.0.1"
+
+	cfg_file="$BATS_TEST_DIRNAME/multi-file.toml"
+	run vrsn check --consistency --config="$cfg_file"
+	assert_failure 5
+	assert_line --partial 'git tag 0.0.1'
+	assert_output --partial 'expected 0.0.1 but package.json has 0.2.0'
+
+	git tag -d "0.0.1"
+}
+
+@test "vrsn sync w. files in config: --highest writes the other files" {
+	git checkout -b "$test_branch"
+	printf '{"version":"0.2.0"}' >package.json
+	git add package.json
+	git commit -m "bump package.json only"
+
+	cfg_file="$BATS_TEST_DIRNAME/multi-file.toml"
+	run vrsn sync --highest --commit --config="$cfg_file"
+	assert_success
+	assert_line --index 0 'version synced to 0.2.0'
+	assert_line --partial 'VERSION  0.0.1 → 0.2.0'
+
+	assert_equal "0.2.0" "$(head -n1 VERSION)"
+	run git --no-pager log -1 --format=%s
+	assert_output 'sync version to 0.2.0'
+
+	git reset --hard "$(git rev-parse HEAD~2)"
+}
+
+@test "vrsn bump w. files in config: updates and commits the package-lock.json" {
+	git checkout -b "$test_branch"
+	printf '{\n  "name": "app",\n  "version": "0.0.1",\n  "packages": {\n    "": {\n      "version": "0.0.1"\n    }\n  }\n}\n' >package-lock.json
+	git add package-lock.json
+	git commit -m "add package-lock.json"
+
+	cfg_file="$BATS_TEST_DIRNAME/multi-file.toml"
+	run vrsn bump minor --config="$cfg_file"
+	assert_success
+	assert_line --index 0 'version bumped from 0.0.1 to 0.1.0'
+
+	run grep -c '"version": "0.1.0"' package-lock.json
+	assert_output '2'
+
+	run git --no-pager diff-tree --no-commit-id --name-only -r HEAD
+	assert_success
+	assert_line 'package-lock.json'
+
+	git reset --hard "$(git rev-parse HEAD~2)"
+}
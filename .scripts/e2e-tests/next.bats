@@ -0,0 +1,77 @@
+#!/usr/bin/env bats
+
+# e2e tests for the `vrsn next` command
+
+main_branch='main'
+test_branch='bats-tests'
+test_dir='/tmp/project-next'
+
+setup_file() {
+	echo "### suite setup ###"
+	load ./setup-git.sh
+	configure-git "$main_branch"
+
+	load ./setup-git-repo.sh
+	setup-git-repo-with-version-file "$test_dir"
+}
+
+teardown_file() {
+	echo "### suite teardown ###"
+	rm -rf "$test_dir"
+}
+
+setup() {
+	echo "### test setup ###"
+	bats_load_library bats-support
+	bats_load_library bats-assert
+	cd "$test_dir" || exit 1
+}
+
+teardown() {
+	echo "### test teardown ###"
+	load ./teardown-git.sh
+	tidy-git-changes "$main_branch" "$test_branch"
+	delete-tags
+}
+
+@test "vrsn next w. VERSION file: prints the next version" {
+	run vrsn next minor
+	assert_success
+	assert_line --index 0 '0.1.0'
+
+	assert_equal "0.0.1" "$(head -n1 VERSION)"
+}
+
+@test "vrsn next w. VERSION file: --all prints every candidate" {
+	run vrsn next --all
+	assert_success
+	assert_line --index 0 'patch: 0.0.2'
+	assert_line --index 1 'minor: 0.1.0'
+	assert_line --index 2 'major: 1.0.0'
+}
+
+@test "vrsn next w. VERSION file: auto uses commits since the version changed" {
+	git checkout -b "$test_branch"
+	git commit --allow-empty -m "fix: handle empty input"
+	git commit --allow-empty -m "feat: add option"
+
+	run vrsn next auto
+	assert_success
+	assert_line --index 0 '0.1.0'
+}
+
+@test "vrsn next w. git tags: auto uses commits since the latest tag" {
+	git checkout -b "$test_branch"
+	git tag -a "0.0.1" -m "Release 0.0.1"
+	git commit --allow-empty -m "feat!: drop old option"
+
+	run vrsn next auto --git-tag
+	assert_success
+	assert_line --index 0 '1.0.0'
+}
+
+@test "vrsn next: errors without an increment type" {
+	run vrsn next
+	assert_failure 2
+	assert_output --partial 'please pass the increment type'
+}
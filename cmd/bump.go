@@ -1,29 +1,78 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
-	"strconv"
+	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/tx3stn/vrsn/internal/buildnumber"
 	"github.com/tx3stn/vrsn/internal/config"
+	"github.com/tx3stn/vrsn/internal/diff"
 	"github.com/tx3stn/vrsn/internal/files"
-	"github.com/tx3stn/vrsn/internal/flags"
 	"github.com/tx3stn/vrsn/internal/git"
+	"github.com/tx3stn/vrsn/internal/hooks"
 	"github.com/tx3stn/vrsn/internal/logger"
+	"github.com/tx3stn/vrsn/internal/output"
 	"github.com/tx3stn/vrsn/internal/prompt"
 	"github.com/tx3stn/vrsn/internal/template"
 	"github.com/tx3stn/vrsn/internal/version"
 )
 
+// bumpOptions are the flag values of the bump command.
+type bumpOptions struct {
+	*globalOptions
+
+	androidVersionCode  bool
+	appleBuildNumber    bool
+	buildNumberStrategy string
+	commit              bool
+	commitMsg           string
+	dryRun              bool
+	gitTag              bool
+	pubspecBuildNumber  bool
+	tagMsg              string
+	tagName             string
+}
+
+// flagConfig returns the config the flag values represent.
+func (o *bumpOptions) flagConfig() config.Config {
+	conf := o.globalOptions.flagConfig()
+	conf.Bump = config.BumpOpts{
+		AndroidVersionCode: o.androidVersionCode,
+		AppleBuildNumber:   o.appleBuildNumber,
+		Commit:             o.commit,
+		CommitMsg:          o.commitMsg,
+		GitTag:             o.gitTag,
+		PubspecBuildNumber: o.pubspecBuildNumber,
+		TagMsg:             o.tagMsg,
+		TagName:            o.tagName,
+	}
+	conf.BuildNumber.Strategy = o.buildNumberStrategy
+	conf.Set = config.SetOpts{
+		AndroidVersionCode: o.androidVersionCode,
+		AppleBuildNumber:   o.appleBuildNumber,
+		PubspecBuildNumber: o.pubspecBuildNumber,
+	}
+
+	return conf
+}
+
 // NewCmdBump creates the bump command.
-func NewCmdBump() *cobra.Command {
+func NewCmdBump(global *globalOptions) *cobra.Command {
+	opts := &bumpOptions{globalOptions: global}
 	shortDescription := "Increment the current semantic version with a valid patch, major or minor bump."
 
 	cmd := &cobra.Command{
 		Args: cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
-		RunE: runBump,
+		RunE: withOutput("bump", global, func(ccmd *cobra.Command, args []string, result *output.Result) error {
+			return runBump(ccmd, args, opts, result)
+		}),
 		//nolint:perfsprint
 		Long: fmt.Sprintf(`%s
 
@@ -42,28 +91,64 @@ The semantic version in the version file will be updated in place.`, shortDescri
 
 	cmd.Flags().
 		BoolVar(
-			&flags.AndroidVersionCode,
+			&opts.androidVersionCode,
 			"android-version-code",
 			false,
-			"Also bump android:versionCode in AndroidManifest files, derived from the new "+
-				"version as MAJOR*10000+MINOR*100+PATCH.",
+			"Also bump android:versionCode in AndroidManifest files, using the build number "+
+				"strategy.",
+		)
+
+	cmd.Flags().
+		BoolVar(
+			&opts.appleBuildNumber,
+			"apple-build-number",
+			false,
+			"Also bump CFBundleVersion in Info.plist and CURRENT_PROJECT_VERSION in "+
+				"project.pbxproj files, using the build number strategy.",
+		)
+
+	cmd.Flags().
+		BoolVar(
+			&opts.pubspecBuildNumber,
+			"pubspec-build-number",
+			false,
+			"Also bump the build number after the + of the version in pubspec.yaml files, "+
+				"using the build number strategy.",
+		)
+
+	cmd.Flags().
+		StringVar(
+			&opts.buildNumberStrategy,
+			"build-number-strategy",
+			config.Default().BuildNumber.Strategy,
+			"How build numbers are derived: formula (MAJOR*10000+MINOR*100+PATCH by default), "+
+				"increment, commit-count or timestamp.",
 		)
 
 	cmd.Flags().
-		BoolVar(&flags.Commit, "commit", false, "Commit the updated version file after bumping.")
+		BoolVar(&opts.commit, "commit", false, "Commit the updated version file after bumping.")
+
+	cmd.Flags().
+		BoolVar(
+			&opts.dryRun,
+			"dry-run",
+			false,
+			"Show a diff of the version file changes, and any commit or tag that would be "+
+				"created, without writing or committing anything.",
+		)
 
 	cmd.Flags().
 		StringVar(
-			&flags.CommitMsg,
+			&opts.commitMsg,
 			"commit-msg",
-			"bump version",
+			config.Default().Bump.CommitMsg,
 			"Customise the commit message used when committing the version bump. "+
-				"Supports Go template syntax with the {{.Version}} variable for the new version.",
+				"Supports Go template syntax, e.g. {{.Version}} for the new version.",
 		)
 
 	cmd.Flags().
 		BoolVar(
-			&flags.GitTag,
+			&opts.gitTag,
 			"git-tag",
 			false,
 			"Bump the git tag only: read the latest tag and write the new tag on the "+
@@ -72,25 +157,36 @@ The semantic version in the version file will be updated in place.`, shortDescri
 
 	cmd.Flags().
 		StringVar(
-			&flags.TagMsg,
+			&opts.tagMsg,
 			"tag-msg",
 			"",
 			"Customise the tag message used when adding the version tag. "+
-				"Supports Go template syntax with the {{.Version}} variable for the new version.",
+				"Supports Go template syntax, e.g. {{.Version}} for the new version.",
+		)
+
+	cmd.Flags().
+		StringVar(
+			&opts.tagName,
+			"tag-name",
+			"",
+			"Customise the name of the version tag, the new version by default. "+
+				"Supports Go template syntax, e.g. v{{.Version | trimPrefix \"v\"}}.",
 		)
 
 	return cmd
 }
 
 // runBump is the entrypoint for the bump command.
-func runBump(ccmd *cobra.Command, args []string) error {
-	// TODO: support color option.
-	conf, err := config.Get(flags.ConfigFile, ccmd.Flags())
+func runBump(ccmd *cobra.Command, args []string, opts *bumpOptions, result *output.Result) error {
+	conf, err := config.Get(opts.configFile, opts.flagConfig(), ccmd.Flags())
 	if err != nil {
 		return fmt.Errorf("error getting config: %w", err)
 	}
 
-	log := logger.NewBasic(false, conf.Verbose)
+	log, err := opts.newLogger(ccmd, conf)
+	if err != nil {
+		return err
+	}
 
 	curDir, err := os.Getwd()
 	if err != nil {
@@ -104,15 +200,19 @@ func runBump(ccmd *cobra.Command, args []string) error {
 	// write the new tag on the current commit. Any version files (from --file
 	// or the config `files` option) are ignored in this mode.
 	if conf.Bump.GitTag {
-		return bumpGitTag(curDir, args, log, conf.Bump.TagMsg)
+		return bumpGitTag(ccmd.Context(), curDir, args, log, conf, opts.hookRunner(ccmd, curDir), opts.dryRun, result)
 	}
 
-	if err := writeVersion(curDir, args, log, conf, writeConfig{
+	if err := writeVersion(ccmd.Context(), curDir, args, log, conf, result, writeConfig{
+		hooks:              opts.hookRunner(ccmd, curDir),
 		resolve:            getNewVersion,
 		verb:               "bumped",
 		commit:             conf.Bump.Commit,
 		commitMsg:          conf.Bump.CommitMsg,
 		androidVersionCode: conf.Bump.AndroidVersionCode,
+		appleBuildNumber:   conf.Bump.AppleBuildNumber,
+		pubspecBuildNumber: conf.Bump.PubspecBuildNumber,
+		dryRun:             opts.dryRun,
 	}); err != nil {
 		return err
 	}
@@ -129,6 +229,8 @@ type versionResolver func(currentVersion string, args []string) (string, error)
 // writeVersion. Reading these explicitly (rather than conf.Bump.*) keeps bump's
 // commit/tag options from leaking into commands that opt out of them.
 type writeConfig struct {
+	// hooks runs the hook commands from the config.
+	hooks hooks.Runner
 	// resolve derives the new version (bump: getNewVersion, set: getSetVersion).
 	resolve versionResolver
 	// verb is the past-tense action used in the summary log ("bumped" / "set").
@@ -137,130 +239,646 @@ type writeConfig struct {
 	commit bool
 	// commitMsg is the (unrendered) commit message template, used when commit.
 	commitMsg string
-	// androidVersionCode, when true, also writes android:versionCode derived
-	// from the new version to any AndroidManifest files.
+	// androidVersionCode, when true, also writes android:versionCode from the
+	// build number strategy to any AndroidManifest files.
 	androidVersionCode bool
+	// appleBuildNumber, when true, also writes CFBundleVersion and
+	// CURRENT_PROJECT_VERSION from the build number strategy to any Info.plist
+	// and Xcode project files.
+	appleBuildNumber bool
+	// pubspecBuildNumber, when true, also writes the build number after the
+	// + of the version in any pubspec.yaml files.
+	pubspecBuildNumber bool
+	// dryRun, when true, prints a diff of each version file and the commit
+	// that would be made instead of writing or committing anything.
+	dryRun bool
 }
 
 // writeVersion finds the version files, resolves and writes the new version to
-// them, logs the change and optionally commits, returning the new version. It
-// is the shared core of the bump and set commands.
+// them, logs the change and optionally commits, recording what it did in the
+// result. It is the shared core of the bump and set commands.
 func writeVersion(
+	ctx context.Context,
 	curDir string,
 	args []string,
-	log logger.Basic,
+	log logger.Logger,
 	conf config.Config,
+	result *output.Result,
 	opts writeConfig,
 ) error {
-	versionFiles, err := resolveVersionFiles(curDir, conf.Files, log, true)
+	versionFiles, currentVersion, newVersion, err := resolveNewVersion(curDir, args, log, conf, opts)
 	if err != nil {
 		return err
 	}
 
-	currentVersion, err := files.GetVersionsFromFiles(curDir, versionFiles, log)
-	if err != nil {
-		return fmt.Errorf("error getting version from files: %w", err)
-	}
+	result.PreviousVersion = currentVersion
+	result.Version = newVersion
+	result.DryRun = opts.dryRun
 
-	newVersion, err := opts.resolve(currentVersion, args)
-	if err != nil {
-		return err
+	// set can move to any version, so the bump type is only recorded when the
+	// change is a valid increment.
+	if bumpType, err := version.BumpType(currentVersion, newVersion); err == nil {
+		result.BumpType = bumpType
 	}
 
 	// Render the commit message before writing so an invalid template errors
 	// before any files are changed.
 	commitMsg := ""
 	if opts.commit {
-		commitMsg, err = template.Render(opts.commitMsg, newVersion)
+		commitMsg, err = renderCommitMsg(ctx, curDir, opts.commitMsg, currentVersion, newVersion, versionFiles)
 		if err != nil {
-			return fmt.Errorf("error rendering commit message: %w", err)
+			return err
+		}
+	}
+
+	writeOpts, err := buildWriteOptions(ctx, curDir, newVersion, conf.BuildNumber, opts)
+	if err != nil {
+		return err
+	}
+
+	hookEnv := hooks.Env{
+		BumpType:        result.BumpType,
+		Files:           versionFiles,
+		NewVersion:      newVersion,
+		PreviousVersion: currentVersion,
+	}
+
+	if !opts.dryRun {
+		if err := opts.hooks.Run(ctx, hooks.PreBump, conf.Hooks.PreBump, hookEnv); err != nil {
+			//nolint:wrapcheck
+			return err
+		}
+	}
+
+	txn := files.Transaction{DryRun: opts.dryRun}
+
+	lockfiles, err := stageVersionFiles(curDir, versionFiles, conf.Anchors, writeOpts, &txn, log)
+	if err != nil {
+		return err
+	}
+
+	result.Files = fileVersions(txn.Changes(), currentVersion, newVersion, opts.dryRun)
+
+	// The hooks after pre-bump see every file written, including lockfiles.
+	hookEnv.Files = changedFiles(txn.Changes())
+
+	if opts.dryRun {
+		printDryRun(result.Files, currentVersion, newVersion, commitMsg, conf.Hooks, opts, log)
+
+		return nil
+	}
+
+	sha, err := applyVersionFiles(ctx, curDir, &txn, versionFiles, lockfiles, commitMsg, conf.Hooks, hookEnv, opts, log)
+	if err != nil {
+		return err
+	}
+
+	result.Commit = sha
+
+	log.Success(fmt.Sprintf(
+		"version %s from %s to %s", opts.verb, log.Highlight(currentVersion), log.Highlight(newVersion),
+	))
+
+	if opts.commit {
+		log.Infof("version file committed")
+	}
+
+	printFileVersions(result.Files, log)
+
+	return nil
+}
+
+// fileVersions returns the version change of each of the staged files, with
+// the diff of the file on a dry run.
+func fileVersions(changes []files.Change, currentVersion string, newVersion string, dryRun bool) []output.FileVersion {
+	fileResults := make([]output.FileVersion, 0, len(changes))
+
+	for _, change := range changes {
+		fileResult := output.FileVersion{
+			File:            change.File,
+			Version:         newVersion,
+			PreviousVersion: currentVersion,
+		}
+
+		if dryRun {
+			fileResult.Diff = diff.Unified(change.File, change.Before, change.After)
 		}
+
+		fileResults = append(fileResults, fileResult)
+	}
+
+	return fileResults
+}
+
+// resolveNewVersion finds the version files and the version they all have,
+// returning them along with the new version resolved from it.
+func resolveNewVersion(
+	curDir string,
+	args []string,
+	log logger.Logger,
+	conf config.Config,
+	opts writeConfig,
+) ([]string, string, string, error) {
+	versionFiles, err := resolveVersionFiles(curDir, conf.Files, log, true)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	currentVersion, err := files.GetVersionsFromFiles(curDir, versionFiles, conf.Anchors, log)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("error getting version from files: %w", err)
+	}
+
+	newVersion, err := opts.resolve(currentVersion, args)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	return versionFiles, currentVersion, newVersion, nil
+}
+
+// renderCommitMsg renders the commit message template for writing the new
+// version to the version files.
+func renderCommitMsg(
+	ctx context.Context,
+	curDir string,
+	commitMsg string,
+	previousVersion string,
+	newVersion string,
+	versionFiles []string,
+) (string, error) {
+	data := template.NewData(previousVersion, newVersion, gitRepo{ctx: ctx, dir: curDir, versionFiles: versionFiles})
+	data.Files = versionFiles
+
+	rendered, err := template.Render(commitMsg, data)
+	if err != nil {
+		return "", fmt.Errorf("error rendering commit message: %w", err)
 	}
 
+	return rendered, nil
+}
+
+// buildWriteOptions returns the options to write the new version with.
+// The version code and build numbers come from the configured strategy, so
+// they are computed once and only when requested, then applied to any
+// AndroidManifest, Info.plist, Xcode project or pubspec.yaml files.
+func buildWriteOptions(
+	ctx context.Context,
+	curDir string,
+	newVersion string,
+	buildNumberConf config.BuildNumberOpts,
+	opts writeConfig,
+) (files.WriteOptions, error) {
 	writeOpts := files.WriteOptions{NewVersion: newVersion}
 
-	// The version code is derived from the numeric part of the new semver, so it
-	// is computed once and only when requested, then applied to any
-	// AndroidManifest files. Any set suffix (e.g. the "-dev" in 1.2.3-dev) is
-	// dropped before parsing, since the version code is an integer. bump always
-	// passes a numeric version, so the split is a no-op there.
+	if !opts.androidVersionCode && !opts.appleBuildNumber && !opts.pubspecBuildNumber {
+		return writeOpts, nil
+	}
+
+	buildNumber, err := buildnumber.New(newVersion, buildnumber.Options{
+		Strategy:    buildNumberConf.Strategy,
+		MinorDigits: buildNumberConf.MinorDigits,
+		PatchDigits: buildNumberConf.PatchDigits,
+		CommitCount: func() (int, error) { return git.CommitCount(ctx, curDir) },
+		Now:         time.Now(),
+	})
+	if err != nil {
+		return files.WriteOptions{}, fmt.Errorf("error deriving build number: %w", err)
+	}
+
 	if opts.androidVersionCode {
-		core, _, _ := strings.Cut(newVersion, "-")
+		writeOpts.AndroidVersionCode = buildNumber
+	}
+
+	if opts.appleBuildNumber {
+		writeOpts.AppleBuildNumber = buildNumber
+	}
+
+	if opts.pubspecBuildNumber {
+		writeOpts.PubspecBuildNumber = buildNumber
+	}
+
+	return writeOpts, nil
+}
+
+// stageVersionFiles stages the new version in each of the version files, with
+// their anchors, and in their lockfiles, returning the lockfiles that were
+// staged. Every file is staged before any are replaced so a file that can't be
+// updated leaves all of them untouched, and the transaction is rolled back
+// when staging fails.
+func stageVersionFiles(
+	curDir string,
+	versionFiles []string,
+	anchors map[string][]string,
+	writeOpts files.WriteOptions,
+	txn *files.Transaction,
+	log logger.Logger,
+) ([]string, error) {
+	for _, versionFile := range versionFiles {
+		writeOpts.Anchors = anchors[versionFile]
 
-		parsed, parseErr := version.Parse(core)
-		if parseErr != nil {
-			return fmt.Errorf(
-				"error parsing new version for android version code: %w",
-				parseErr,
+		if err := txn.Stage(curDir, versionFile, writeOpts); err != nil {
+			return nil, errors.Join(
+				fmt.Errorf("error writing version to file %s: %w", versionFile, err),
+				txn.Rollback(),
 			)
 		}
+	}
+
+	lockfiles, err := stageLockfiles(curDir, versionFiles, writeOpts.NewVersion, txn, log)
+	if err != nil {
+		return nil, errors.Join(err, txn.Rollback())
+	}
 
-		writeOpts.AndroidVersionCode = strconv.Itoa(parsed.AndroidVersionCode())
+	return lockfiles, nil
+}
+
+// applyVersionFiles replaces the version files with their staged changes, runs
+// the hooks and optionally commits the files, restoring them if a hook fails,
+// and returns the SHA of the commit when there is one.
+func applyVersionFiles(
+	ctx context.Context,
+	curDir string,
+	txn *files.Transaction,
+	versionFiles []string,
+	lockfiles []string,
+	commitMsg string,
+	hooksConf config.HooksOpts,
+	hookEnv hooks.Env,
+	opts writeConfig,
+	log logger.Logger,
+) (string, error) {
+	if err := txn.Commit(); err != nil {
+		return "", fmt.Errorf("error writing version to files: %w", err)
 	}
 
 	for _, versionFile := range versionFiles {
-		if err := files.WriteVersionToFile(curDir, versionFile, writeOpts); err != nil {
-			return fmt.Errorf("error writing version to file %s: %w", versionFile, err)
+		log.Debugf("%s version in %s", opts.verb, versionFile)
+	}
+
+	if err := runWriteHooks(ctx, curDir, txn, hooksConf, hookEnv, opts); err != nil {
+		log.Warn("restoring version files after failed hook")
+
+		return "", errors.Join(err, txn.Rollback())
+	}
+
+	if !opts.commit {
+		return "", nil
+	}
+
+	return commitWrittenFiles(ctx, curDir, txn, versionFiles, lockfiles, hooksConf.CommitFiles, commitMsg, log)
+}
+
+// printFileVersions logs a table of the version change in each file when more
+// than one file was updated, a single file is covered by the summary line.
+func printFileVersions(fileResults []output.FileVersion, log logger.Logger) {
+	if len(fileResults) < 2 {
+		return
+	}
+
+	rows := make([][]string, 0, len(fileResults))
+
+	for _, fileResult := range fileResults {
+		rows = append(rows, []string{
+			fileResult.File,
+			fileResult.PreviousVersion + " → " + log.Highlight(fileResult.Version),
+		})
+	}
+
+	log.Table(rows)
+}
+
+// printDryRun logs the diff of each version file, and the commit and hooks
+// that writing the version would make and run.
+func printDryRun(
+	fileResults []output.FileVersion,
+	currentVersion string,
+	newVersion string,
+	commitMsg string,
+	hooksConf config.HooksOpts,
+	opts writeConfig,
+	log logger.Logger,
+) {
+	for _, fileResult := range fileResults {
+		if fileResult.Diff == "" {
+			log.Infof("no changes to %s", fileResult.File)
+
+			continue
 		}
 
-		log.Debugf("%s version in %s", opts.verb, versionFile)
+		log.Info(strings.TrimSuffix(fileResult.Diff, "\n"))
 	}
 
-	log.Infof("version %s from %s to %s", opts.verb, currentVersion, newVersion)
+	log.Infof("dry run: version would be %s from %s to %s", opts.verb, currentVersion, newVersion)
 
 	if opts.commit {
-		if err := commitVersionFiles(curDir, versionFiles, commitMsg, log); err != nil {
-			return err
+		log.Infof("dry run: version files would be committed with message: %s", commitMsg)
+	}
+
+	printDryRunHooks(hooks.PreBump, hooksConf.PreBump, log)
+	printDryRunHooks(hooks.PostWrite, hooksConf.PostWrite, log)
+
+	if opts.commit {
+		printDryRunHooks(hooks.PreCommit, hooksConf.PreCommit, log)
+	}
+}
+
+// runWriteHooks runs the post-write hooks, and the pre-commit hooks when
+// committing, once the version files are written. The files the hooks are
+// expected to change are tracked first, so they are restored along with the
+// version files if a hook fails.
+func runWriteHooks(
+	ctx context.Context,
+	curDir string,
+	txn *files.Transaction,
+	hooksConf config.HooksOpts,
+	hookEnv hooks.Env,
+	opts writeConfig,
+) error {
+	for _, commitFile := range hooksConf.CommitFiles {
+		if err := txn.Track(curDir, commitFile); err != nil {
+			return fmt.Errorf("error tracking hook file %s: %w", commitFile, err)
 		}
 	}
 
-	return nil
+	if err := opts.hooks.Run(ctx, hooks.PostWrite, hooksConf.PostWrite, hookEnv); err != nil {
+		//nolint:wrapcheck
+		return err
+	}
+
+	if !opts.commit {
+		return nil
+	}
+
+	//nolint:wrapcheck
+	return opts.hooks.Run(ctx, hooks.PreCommit, hooksConf.PreCommit, hookEnv)
+}
+
+// changedFiles returns the files the changes modify, skipping any staged file,
+// e.g. a lockfile, that already had the new version.
+func changedFiles(changes []files.Change) []string {
+	changed := make([]string, 0, len(changes))
+
+	for _, change := range changes {
+		if change.Before != change.After {
+			changed = append(changed, change.File)
+		}
+	}
+
+	return changed
+}
+
+// stageLockfiles stages the version of the packages in the lockfile of each of
+// the version files, e.g. package-lock.json for package.json, returning the
+// lockfiles that were staged. Packages in a workspace share a lockfile, so
+// their entries are all updated together.
+func stageLockfiles(
+	curDir string,
+	versionFiles []string,
+	newVersion string,
+	txn *files.Transaction,
+	log logger.Logger,
+) ([]string, error) {
+	lockfiles := []string{}
+	manifests := map[string][]string{}
+
+	for _, versionFile := range versionFiles {
+		lockfile, ok := files.FindLockfile(curDir, versionFile)
+		if !ok {
+			continue
+		}
+
+		if _, seen := manifests[lockfile]; !seen {
+			lockfiles = append(lockfiles, lockfile)
+		}
+
+		manifests[lockfile] = append(manifests[lockfile], versionFile)
+	}
+
+	staged := []string{}
+
+	for _, lockfile := range lockfiles {
+		ok, err := txn.StageLockfile(curDir, lockfile, manifests[lockfile], newVersion)
+		if err != nil {
+			return nil, fmt.Errorf("error writing version to lockfile %s: %w", lockfile, err)
+		}
+
+		if !ok {
+			log.Debugf("no entry for %s in lockfile %s", strings.Join(manifests[lockfile], ", "), lockfile)
+
+			continue
+		}
+
+		staged = append(staged, lockfile)
+	}
+
+	return staged, nil
+}
+
+// filesToCommit returns the version files along with their lockfiles that are
+// tracked by git, as some projects ignore them, e.g. Cargo.lock in libraries,
+// and the files the hooks changed that exist, as a hook may only create a file
+// in some cases.
+func filesToCommit(
+	ctx context.Context,
+	curDir string,
+	versionFiles []string,
+	lockfiles []string,
+	hookFiles []string,
+) ([]string, error) {
+	commitFiles := slices.Clone(versionFiles)
+
+	if len(lockfiles) > 0 {
+		tracked, err := git.TrackedFiles(ctx, curDir, lockfiles...)
+		if err != nil {
+			return nil, fmt.Errorf("error checking lockfiles are tracked: %w", err)
+		}
+
+		commitFiles = append(commitFiles, tracked...)
+	}
+
+	for _, hookFile := range hookFiles {
+		if slices.Contains(commitFiles, hookFile) {
+			continue
+		}
+
+		path := hookFile
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(curDir, path)
+		}
+
+		if _, err := os.Stat(path); err == nil {
+			commitFiles = append(commitFiles, hookFile)
+		}
+	}
+
+	return commitFiles, nil
+}
+
+// printDryRunHooks logs the hook commands of the stage a dry run would run.
+func printDryRunHooks(stage hooks.Stage, commands []string, log logger.Logger) {
+	for _, command := range commands {
+		log.Infof("dry run: %s hook would run: %s", stage, command)
+	}
+}
+
+// commitWrittenFiles commits the version files that were written along with
+// their lockfiles and the files the hooks changed, restoring them and removing
+// them from the git staging area if the commit fails, and returns the SHA of
+// the commit, or an empty string when it can't be read after committing. It is
+// shared by the commands that write version files.
+func commitWrittenFiles(
+	ctx context.Context,
+	curDir string,
+	txn *files.Transaction,
+	versionFiles []string,
+	lockfiles []string,
+	hookFiles []string,
+	commitMsg string,
+	log logger.Logger,
+) (string, error) {
+	commitFiles, err := filesToCommit(ctx, curDir, versionFiles, lockfiles, hookFiles)
+	if err != nil {
+		return "", errors.Join(err, txn.Rollback())
+	}
+
+	if err := commitVersionFiles(ctx, curDir, commitFiles, commitMsg, log); err != nil {
+		log.Warn("restoring version files after failed commit")
+
+		return "", errors.Join(err, txn.Rollback(), unstageVersionFiles(ctx, curDir, commitFiles))
+	}
+
+	// The files are committed at this point, so failing to read the SHA back
+	// only leaves it out of the result rather than failing the command.
+	sha, err := git.HeadCommit(ctx, curDir)
+	if err != nil {
+		log.Warn("version files committed but the commit could not be read: " + err.Error())
+
+		return "", nil
+	}
+
+	return sha, nil
 }
 
 // commitVersionFiles stages the bumped version files and commits them all in
 // a single commit.
 func commitVersionFiles(
+	ctx context.Context,
 	curDir string,
 	versionFiles []string,
 	commitMsg string,
-	log logger.Basic,
+	log logger.Logger,
 ) error {
-	addOutput, err := git.Add(curDir, versionFiles...)
+	addOutput, err := git.Add(ctx, curDir, versionFiles...)
 	if err != nil {
 		log.Infof("git add output: %s", addOutput)
 
 		return fmt.Errorf("error git adding files: %w", err)
 	}
 
-	commitOutput, err := git.Commit(curDir, commitMsg, versionFiles...)
+	commitOutput, err := git.Commit(ctx, curDir, commitMsg, versionFiles...)
 	if err != nil {
 		log.Infof("git commit output: %s", commitOutput)
 
 		return fmt.Errorf("error git committing files: %w", err)
 	}
 
-	log.Infof("version file committed")
+	return nil
+}
+
+// unstageVersionFiles removes the version files from the git staging area
+// once they have been restored after a failed commit, so the index doesn't
+// hold the abandoned version.
+func unstageVersionFiles(ctx context.Context, curDir string, versionFiles []string) error {
+	if _, err := git.Unstage(ctx, curDir, versionFiles...); err != nil {
+		return fmt.Errorf("error unstaging version files: %w", err)
+	}
 
 	return nil
 }
 
-// applyGitTag adds the new version as an annotated git tag, defaulting the tag
-// message when one isn't provided.
-func applyGitTag(curDir string, newVersion string, tagMsg string) error {
+// renderTag renders the name and message of the tag for the new version,
+// defaulting the name to the new version and the message to "Release" followed
+// by it when they aren't provided.
+func renderTag(data template.Data, tagName string, tagMsg string) (string, string, error) {
+	if tagName == "" {
+		tagName = data.Version
+	}
+
 	if tagMsg == "" {
-		tagMsg = "Release " + newVersion
+		tagMsg = "Release " + data.Version
 	}
 
-	renderedMsg, err := template.Render(tagMsg, newVersion)
+	renderedName, err := template.Render(tagName, data)
 	if err != nil {
-		return fmt.Errorf("error rendering tag message: %w", err)
+		return "", "", fmt.Errorf("error rendering tag name: %w", err)
 	}
 
-	if err := git.AddTag(curDir, newVersion, renderedMsg); err != nil {
-		return fmt.Errorf("error adding tag: %w", err)
+	renderedMsg, err := template.Render(tagMsg, data)
+	if err != nil {
+		return "", "", fmt.Errorf("error rendering tag message: %w", err)
 	}
 
-	return nil
+	return renderedName, renderedMsg, nil
+}
+
+// gitRepo looks up the git variables of message templates in the repository
+// of dir, see template.Repo.
+//
+//nolint:containedctx // only used for the lifetime of rendering the messages.
+type gitRepo struct {
+	ctx context.Context
+	dir string
+	// previousTag is the tag of the previous version when bumping a git tag,
+	// otherwise the commits since the last change to the versionFiles are
+	// used.
+	previousTag  string
+	versionFiles []string
+}
+
+// Branch returns the name of the current branch.
+func (r gitRepo) Branch() (string, error) {
+	//nolint:wrapcheck
+	return git.CurrentBranch(r.ctx, r.dir)
+}
+
+// Commits returns the subject of each commit since the previous version.
+func (r gitRepo) Commits() ([]string, error) {
+	since := r.previousTag
+	if since == "" {
+		var err error
+
+		since, err = git.LastCommitTouching(r.ctx, r.dir, r.versionFiles...)
+		if err != nil {
+			//nolint:wrapcheck
+			return nil, err
+		}
+	}
+
+	messages, err := git.CommitMessagesSince(r.ctx, r.dir, since)
+	if err != nil {
+		//nolint:wrapcheck
+		return nil, err
+	}
+
+	subjects := make([]string, 0, len(messages))
+	for _, message := range messages {
+		subject, _, _ := strings.Cut(message, "\n")
+		subjects = append(subjects, subject)
+	}
+
+	return subjects, nil
+}
+
+// Package returns the directory relative to the root of the repository.
+func (r gitRepo) Package() (string, error) {
+	prefix, err := git.PathPrefix(r.ctx, r.dir)
+	if err != nil {
+		//nolint:wrapcheck
+		return "", err
+	}
+
+	return strings.TrimSuffix(prefix, "/"), nil
 }
 
 func getNewVersion(currentVersion string, args []string) (string, error) {
@@ -286,8 +904,17 @@ func getNewVersion(currentVersion string, args []string) (string, error) {
 	return newVersion, nil
 }
 
-func bumpGitTag(curDir string, args []string, log logger.Basic, tagMsg string) error {
-	currentVersion, err := git.LatestTag(curDir)
+func bumpGitTag(
+	ctx context.Context,
+	curDir string,
+	args []string,
+	log logger.Logger,
+	conf config.Config,
+	hookRunner hooks.Runner,
+	dryRun bool,
+	result *output.Result,
+) error {
+	currentVersion, err := git.LatestTag(ctx, curDir)
 	if err != nil {
 		return fmt.Errorf("error getting latest tag: %w", err)
 	}
@@ -299,11 +926,51 @@ func bumpGitTag(curDir string, args []string, log logger.Basic, tagMsg string) e
 		return err
 	}
 
-	if err := applyGitTag(curDir, newVersion, tagMsg); err != nil {
+	result.PreviousVersion = currentVersion
+	result.Version = newVersion
+	result.DryRun = dryRun
+
+	if bumpType, err := version.BumpType(currentVersion, newVersion); err == nil {
+		result.BumpType = bumpType
+	}
+
+	data := template.NewData(currentVersion, newVersion, gitRepo{ctx: ctx, dir: curDir, previousTag: currentVersion})
+
+	tagName, tagMsg, err := renderTag(data, conf.Bump.TagName, conf.Bump.TagMsg)
+	if err != nil {
+		return err
+	}
+
+	if dryRun {
+		log.Infof(
+			"dry run: git tag would be bumped from %s to %s with message: %s",
+			currentVersion,
+			tagName,
+			tagMsg,
+		)
+
+		printDryRunHooks(hooks.PostTag, conf.Hooks.PostTag, log)
+
+		return nil
+	}
+
+	if err := git.AddTag(ctx, curDir, tagName, tagMsg); err != nil {
+		return fmt.Errorf("error adding tag: %w", err)
+	}
+
+	result.Tag = tagName
+
+	// The tag is already created, so a failing post-tag hook is reported
+	// without removing it.
+	hookEnv := hooks.Env{BumpType: result.BumpType, NewVersion: newVersion, PreviousVersion: currentVersion}
+	if err := hookRunner.Run(ctx, hooks.PostTag, conf.Hooks.PostTag, hookEnv); err != nil {
+		//nolint:wrapcheck
 		return err
 	}
 
-	log.Infof("git tag version bumped from %s to %s", currentVersion, newVersion)
+	log.Success(fmt.Sprintf(
+		"git tag version bumped from %s to %s", log.Highlight(currentVersion), log.Highlight(newVersion),
+	))
 
 	return nil
 }
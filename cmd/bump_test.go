@@ -0,0 +1,49 @@
+package cmd_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tx3stn/vrsn/internal/hooks"
+)
+
+func TestBumpRestoresVersionFilesAfterFailedHook(t *testing.T) {
+	dir := writeProject(t, map[string]string{
+		"vrsn.toml": "files = ['VERSION']\n[hooks]\npost-write = ['exit 1']\n",
+		"VERSION":   "1.2.0\n",
+	})
+
+	stdout, stderr, err := runInDir(t, dir, "bump", "patch")
+	require.ErrorIs(t, err, hooks.ErrHookFailed)
+
+	assert.NotContains(t, stdout, "restoring version files")
+	assert.Contains(t, stderr, "restoring version files after failed hook")
+
+	content, err := os.ReadFile(filepath.Join(dir, "VERSION"))
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.0\n", string(content))
+}
+
+func TestBumpHooksListTheLockfilesInTheChangedFiles(t *testing.T) {
+	dir := writeProject(t, map[string]string{
+		"vrsn.toml": "files = ['package.json']\n[hooks]\n" +
+			"pre-bump = ['echo \"$VRSN_HOOK_CHANGED_FILES\" > pre-bump.txt']\n" +
+			"post-write = ['echo \"$VRSN_HOOK_CHANGED_FILES\" > post-write.txt']\n",
+		"package.json":      `{"name":"app","version":"1.2.0"}`,
+		"package-lock.json": `{"name":"app","version":"1.2.0","packages":{"":{"version":"1.2.0"}}}`,
+	})
+
+	_, _, err := runInDir(t, dir, "bump", "patch")
+	require.NoError(t, err)
+
+	preBump, err := os.ReadFile(filepath.Join(dir, "pre-bump.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "package.json\n", string(preBump))
+
+	postWrite, err := os.ReadFile(filepath.Join(dir, "post-write.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "package.json package-lock.json\n", string(postWrite))
+}
@@ -1,24 +1,56 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/tx3stn/vrsn/internal/buildnumber"
 	"github.com/tx3stn/vrsn/internal/config"
 	"github.com/tx3stn/vrsn/internal/files"
-	"github.com/tx3stn/vrsn/internal/flags"
 	"github.com/tx3stn/vrsn/internal/git"
 	"github.com/tx3stn/vrsn/internal/logger"
+	"github.com/tx3stn/vrsn/internal/output"
 	"github.com/tx3stn/vrsn/internal/version"
+	"github.com/tx3stn/vrsn/pkg/vrsn"
 )
 
+// checkOptions are the flag values of the check command.
+type checkOptions struct {
+	*globalOptions
+
+	baseBranch   string
+	consistency  bool
+	maxVersion   string
+	now          string
+	versionRange string
+	was          string
+}
+
+// flagConfig returns the config the flag values represent.
+func (o *checkOptions) flagConfig() config.Config {
+	conf := o.globalOptions.flagConfig()
+	conf.Check.BaseBranch = o.baseBranch
+	conf.Check.MaxVersion = o.maxVersion
+	conf.Check.Range = o.versionRange
+
+	return conf
+}
+
 // NewCmdCheck creates the check command.
-func NewCmdCheck() *cobra.Command {
+func NewCmdCheck(global *globalOptions) *cobra.Command {
+	opts := &checkOptions{globalOptions: global}
+
 	shortDescription := "Check the semantic version has been correctly incremented."
 
 	cmd := &cobra.Command{
-		RunE: runCheck,
+		RunE: withOutput("check", global, func(ccmd *cobra.Command, args []string, result *output.Result) error {
+			return runCheck(ccmd, args, opts, result)
+		}),
 		//nolint:perfsprint
 		Long: fmt.Sprintf(`%s
 
@@ -32,6 +64,9 @@ That's all you need!
 
 You can also use the --was and --now flags to compare the versions so you can
 read them from A N Y W H E R E.
+
+Use the --consistency flag to check every version file and the latest git tag
+contain the same version instead, which works on the base branch too.
 `, shortDescription),
 		Short:         shortDescription,
 		SilenceErrors: true,
@@ -40,29 +75,56 @@ read them from A N Y W H E R E.
 	}
 	cmd.Flags().
 		StringVar(
-			&flags.BaseBranch,
+			&opts.baseBranch,
 			"base-branch",
-			"main",
+			config.Default().Check.BaseBranch,
 			"Name of the base branch used when auto detecting version changes.",
 		)
 
 	cmd.Flags().
-		StringVar(&flags.Was, "was", "", "The previous semantic version (if passing for direct comparison).")
+		BoolVar(
+			&opts.consistency,
+			"consistency",
+			false,
+			"Check every version file, the latest git tag and any Android versionCode agree, "+
+				"rather than checking the version was bumped.",
+		)
+
+	cmd.Flags().
+		StringVar(
+			&opts.maxVersion,
+			"max-version",
+			"",
+			"Highest version allowed, e.g. 1.x to stay on 1.x or 1.9.9.",
+		)
+
+	cmd.Flags().
+		StringVar(
+			&opts.versionRange,
+			"range",
+			"",
+			"Version range the new version must be in, e.g. '^1.2 || ~2.0'.",
+		)
+
+	cmd.Flags().
+		StringVar(&opts.was, "was", "", "The previous semantic version (if passing for direct comparison).")
 	cmd.Flags().
-		StringVar(&flags.Now, "now", "", "The current semantic version (if passing for direct comparison).")
+		StringVar(&opts.now, "now", "", "The current semantic version (if passing for direct comparison).")
 
 	return cmd
 }
 
 // runCheck is the entrypoint for the check command.
-func runCheck(ccmd *cobra.Command, args []string) error {
-	// TODO: support color option.
-	conf, err := config.Get(flags.ConfigFile, ccmd.Flags())
+func runCheck(ccmd *cobra.Command, args []string, opts *checkOptions, result *output.Result) error {
+	conf, err := config.Get(opts.configFile, opts.flagConfig(), ccmd.Flags())
 	if err != nil {
 		return fmt.Errorf("error getting config: %w", err)
 	}
 
-	log := logger.NewBasic(false, conf.Verbose)
+	log, err := opts.newLogger(ccmd, conf)
+	if err != nil {
+		return err
+	}
 
 	curDir, err := os.Getwd()
 	if err != nil {
@@ -72,132 +134,270 @@ func runCheck(ccmd *cobra.Command, args []string) error {
 	log.Debugf("config: %+v", conf)
 	log.Debugf("check command args: %s", args)
 
-	if flags.Was != "" && flags.Now != "" {
-		return validateAndCompare(log, flags.Was, flags.Now)
+	if opts.consistency {
+		return checkConsistency(ccmd.Context(), curDir, conf, log, result)
 	}
 
-	currentBranch, err := git.CurrentBranch(curDir)
-	if err != nil {
-		return fmt.Errorf("error getting current git branch: %w", err)
+	checkOpts := []vrsn.Option{
+		vrsn.WithAnchors(conf.Anchors),
+		vrsn.WithBaseBranch(conf.Check.BaseBranch),
+		vrsn.WithBranchPolicies(conf.Check.Branches),
+		vrsn.WithDir(curDir),
+		vrsn.WithMaxVersion(conf.Check.MaxVersion),
+		vrsn.WithNow(opts.now),
+		vrsn.WithRange(conf.Check.Range),
+		vrsn.WithWas(opts.was),
 	}
 
-	log.Debugf("current branch: %s", currentBranch)
+	if opts.was == "" || opts.now == "" {
+		versionFiles, err := resolveVersionFiles(curDir, conf.Files, log, false)
+		if err != nil {
+			return fmt.Errorf("error locating version file: %w", err)
+		}
 
-	versionFiles, err := resolveVersionFiles(curDir, conf.Files, log, false)
-	if err != nil {
-		return fmt.Errorf("error locating version file: %w", err)
+		if err := requireVersionFiles(versionFiles, opts.now, log); err != nil {
+			return err
+		}
+
+		checkOpts = append(checkOpts, vrsn.WithFiles(versionFiles...))
 	}
 
-	now, err := resolveNowVersion(curDir, versionFiles, log)
-	if err != nil {
-		return err
+	checkResult, err := vrsn.Check(ccmd.Context(), checkOpts...)
+
+	recordCheck(log, checkResult, result)
+
+	if errors.Is(err, vrsn.ErrOnBaseBranch) {
+		return fmt.Errorf("%w: base branch: %s", ErrCantCompareVersionsOnBranch, conf.Check.BaseBranch)
 	}
 
-	was, err := resolveWasVersion(curDir, currentBranch, conf.Check.BaseBranch, versionFiles, log)
 	if err != nil {
+		//nolint:wrapcheck
 		return err
 	}
 
-	return validateAndCompare(log, was, now)
+	log.Success("valid version bump")
+
+	return nil
 }
 
-// resolveNowVersion returns the version provided with the --now flag, falling
-// back to the version in the version files when the flag isn't set.
-func resolveNowVersion(curDir string, versionFiles []string, log logger.Basic) (string, error) {
-	if flags.Now != "" {
-		return flags.Now, nil
+// requireVersionFiles returns an error pointing at the missing --now or --was
+// flag when there are no version files to read it from instead.
+func requireVersionFiles(versionFiles []string, now string, log logger.Logger) error {
+	if len(versionFiles) > 0 {
+		return nil
 	}
 
-	if len(versionFiles) == 0 {
+	if now == "" {
 		log.Info("no version files found in directory and no --now flag provided")
 
-		return "", ErrNoNowOrFile
+		return ErrNoNowOrFile
 	}
 
-	now, err := files.GetVersionsFromFiles(curDir, versionFiles, log)
-	if err != nil {
-		return "", fmt.Errorf("error reading version from files: %w", err)
+	log.Info("no version files found in directory and no --was flag provided")
+
+	return ErrNoWasOrFile
+}
+
+// recordCheck logs the versions check compared and records what it found in
+// the result.
+func recordCheck(log logger.Logger, checkResult vrsn.CheckResult, result *output.Result) {
+	if checkResult.Was == "" || checkResult.Now == "" {
+		return
 	}
 
-	return now, nil
+	log.Infof("was: %s", log.Highlight(checkResult.Was))
+	log.Infof("now: %s", log.Highlight(checkResult.Now))
+
+	result.PreviousVersion = checkResult.Was
+	result.Version = checkResult.Now
+	result.BumpType = checkResult.BumpType
+
+	for _, versionFile := range checkResult.Files {
+		result.Files = append(result.Files, output.FileVersion{
+			File:            versionFile,
+			Version:         checkResult.Now,
+			PreviousVersion: checkResult.Was,
+		})
+	}
+
+	if checkResult.Range != "" {
+		satisfies := checkResult.Satisfies
+		result.Constraint = checkResult.Range
+		result.Satisfies = &satisfies
+	}
+
+	if checkResult.Policy != "" {
+		result.Branch = checkResult.Branch
+		result.Policy = checkResult.Policy
+	}
 }
 
-// resolveWasVersion returns the version provided with the --was flag, falling
-// back to the version the version files contained at the base branch when the
-// flag isn't set.
-func resolveWasVersion(
+// checkConsistency logs a table of the version in each version file and the
+// latest git tag, along with the versionCode of any AndroidManifest files, and
+// returns an error naming every source that doesn't match.
+func checkConsistency(
+	ctx context.Context,
 	curDir string,
-	currentBranch string,
-	baseBranch string,
-	versionFiles []string,
-	log logger.Basic,
-) (string, error) {
-	if flags.Was != "" {
-		return flags.Was, nil
+	conf config.Config,
+	log logger.Logger,
+	result *output.Result,
+) error {
+	versionFiles, err := resolveVersionFiles(curDir, conf.Files, log, true)
+	if err != nil {
+		return fmt.Errorf("error locating version file: %w", err)
 	}
 
-	if len(versionFiles) == 0 {
-		log.Info("no version files found in directory and no --was flag provided")
+	fileSources, err := readFileVersions(curDir, versionFiles, conf.Anchors)
+	if err != nil {
+		return err
+	}
 
-		return "", ErrNoWasOrFile
+	// Compare the versions without any v prefix, so the files and the tag match
+	// whether or not each of them is v prefixed.
+	sources := make([]files.SourceVersion, 0, len(fileSources)+1)
+	for _, source := range fileSources {
+		sources = append(sources, files.SourceVersion{Source: source.Source, Version: strings.TrimPrefix(source.Version, "v")})
 	}
 
-	if currentBranch == baseBranch {
-		return "", fmt.Errorf(
-			"%w: base branch: %s",
-			ErrCantCompareVersionsOnBranch,
-			baseBranch,
-		)
+	tag, err := git.LatestTag(ctx, curDir)
+
+	switch {
+	case errors.Is(err, git.ErrNoGitTags):
+		log.Debug("no version tags found, skipping the git tag")
+	case err != nil:
+		return fmt.Errorf("error getting latest tag: %w", err)
+	default:
+		sources = append(sources, files.SourceVersion{Source: "git tag " + tag, Version: strings.TrimPrefix(tag, "v")})
+	}
+
+	expected, _ := files.Outliers(sources)
+
+	for _, source := range sources {
+		result.Sources = append(result.Sources, output.Source{
+			Source:   source.Source,
+			Version:  source.Version,
+			Expected: expected,
+			Matches:  source.Version == expected,
+		})
 	}
 
-	return getWasVersionFromFiles(curDir, baseBranch, versionFiles, log)
+	buildNumberErrs, err := checkAndroidVersionCodes(curDir, fileSources, conf.BuildNumber, log, result)
+	if err != nil {
+		return err
+	}
+
+	printSources(result.Sources, log)
+
+	_, versionErr := files.CommonVersion(sources)
+	if err := errors.Join(append([]error{versionErr}, buildNumberErrs...)...); err != nil {
+		//nolint:wrapcheck
+		return err
+	}
+
+	result.Version = expected
+
+	log.Success("all version sources match")
+
+	return nil
 }
 
-// getWasVersionFromFiles reads the version each of the files contained at the
-// base branch and returns the common version they all had.
-// The version found in each file is debug logged, and if the versions do not
-// all match an ErrVersionsDoNotMatch error is returned.
-func getWasVersionFromFiles(
+// readFileVersions reads the version from each of the version files, without
+// requiring them to match.
+func readFileVersions(
 	curDir string,
-	baseBranch string,
 	versionFiles []string,
-	log logger.Basic,
-) (string, error) {
-	versions := make([]string, 0, len(versionFiles))
+	anchors map[string][]string,
+) ([]files.SourceVersion, error) {
+	sources := make([]files.SourceVersion, 0, len(versionFiles))
+
+	for _, versionFile := range versionFiles {
+		version, err := files.GetVersionFromFile(
+			curDir,
+			versionFile,
+			files.ReadOptions{Anchors: anchors[versionFile]},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error getting version from file %s: %w", versionFile, err)
+		}
+
+		sources = append(sources, files.SourceVersion{Source: versionFile, Version: version})
+	}
+
+	return sources, nil
+}
+
+// checkAndroidVersionCodes records the android:versionCode of each
+// AndroidManifest file against the one derived from its version, returning an
+// error for each that doesn't match. The versionCode can only be derived with
+// the formula build number strategy, so nothing is checked with the others.
+func checkAndroidVersionCodes(
+	curDir string,
+	versionFiles []files.SourceVersion,
+	opts config.BuildNumberOpts,
+	log logger.Logger,
+	result *output.Result,
+) ([]error, error) {
+	mismatches := []error{}
 
 	for _, versionFile := range versionFiles {
-		baseBranchVersion, err := git.VersionAtBranch(curDir, baseBranch, versionFile)
+		if matched, _ := filepath.Match("AndroidManifest*.xml", filepath.Base(versionFile.Source)); !matched {
+			continue
+		}
+
+		if opts.Strategy != buildnumber.Formula {
+			log.Debugf("skipping %s versionCode, it is only derived by the %s strategy", versionFile.Source, buildnumber.Formula)
+
+			continue
+		}
+
+		actual, err := files.GetBuildNumberFromFile(curDir, versionFile.Source)
+		if errors.Is(err, files.ErrGettingVersionCodeFromAndroidManifest) {
+			log.Debugf("skipping %s versionCode: %s", versionFile.Source, err)
+
+			continue
+		}
+
 		if err != nil {
-			return "", fmt.Errorf("error getting version at branch: %w", err)
+			return nil, fmt.Errorf("error getting build number from file %s: %w", versionFile.Source, err)
 		}
 
-		was, err := files.GetVersionFromString(versionFile, baseBranchVersion)
+		derived, err := version.NumericCode(versionFile.Version, opts.MinorDigits, opts.PatchDigits)
 		if err != nil {
-			return "", fmt.Errorf("error parsing the version from string: %w", err)
+			return nil, fmt.Errorf("error deriving versionCode of %s: %w", versionFile.Source, err)
 		}
 
-		log.Debugf("file %s has version %s on branch %s", versionFile, was, baseBranch)
+		source := versionFile.Source + " versionCode"
+
+		result.Sources = append(result.Sources, output.Source{
+			Source:   source,
+			Version:  actual,
+			Expected: derived,
+			Matches:  actual == derived,
+		})
 
-		versions = append(versions, was)
+		if actual != derived {
+			mismatches = append(mismatches, fmt.Errorf(
+				"%w: expected %s but %s has %s", ErrBuildNumberDoesNotMatch, derived, source, actual,
+			))
+		}
 	}
 
-	//nolint:wrapcheck
-	return files.CommonVersion(versions)
+	return mismatches, nil
 }
 
-func validateAndCompare(log logger.Basic, was string, now string) error {
-	if err := flags.Validate(was, now); err != nil {
-		return fmt.Errorf("error validating flags: %w", err)
-	}
+// printSources logs a table of the version in each source, marking the ones
+// that don't have the expected version.
+func printSources(sources []output.Source, log logger.Logger) {
+	rows := make([][]string, 0, len(sources))
 
-	log.Infof("was: %s", was)
-	log.Infof("now: %s", now)
+	for _, source := range sources {
+		status := "✓"
+		if !source.Matches {
+			status = "✗ expected " + source.Expected
+		}
 
-	if err := version.Compare(was, now); err != nil {
-		return fmt.Errorf("error comparing versions: %w", err)
+		rows = append(rows, []string{source.Source, log.Highlight(source.Version), status})
 	}
 
-	log.Info("valid version bump")
-
-	return nil
+	log.Table(rows)
 }
@@ -0,0 +1,62 @@
+package cmd_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tx3stn/vrsn/internal/files"
+)
+
+func TestCheckComparesVersionsWithoutTheVPrefix(t *testing.T) {
+	prefixed, err := os.ReadFile(filepath.Join("..", "internal", "files", "testdata", "prefixed", "VERSION"))
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		version        string
+		gitTag         string
+		expectedOutput string
+		expectedError  error
+	}{
+		"MatchesVPrefixedFileAndTag": {
+			version:        string(prefixed),
+			gitTag:         "v6.6.5",
+			expectedOutput: "all version sources match",
+			expectedError:  nil,
+		},
+		"MatchesUnprefixedFileAndVPrefixedTag": {
+			version:        "6.6.5\n",
+			gitTag:         "v6.6.5",
+			expectedOutput: "all version sources match",
+			expectedError:  nil,
+		},
+		"MatchesVPrefixedFileAndUnprefixedTag": {
+			version:        string(prefixed),
+			gitTag:         "6.6.5",
+			expectedOutput: "all version sources match",
+			expectedError:  nil,
+		},
+		"ReturnsErrorForDifferentVersions": {
+			version:        string(prefixed),
+			gitTag:         "v6.6.4",
+			expectedOutput: "",
+			expectedError:  files.ErrVersionsDoNotMatch,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			dir := writeProject(t, map[string]string{
+				"vrsn.toml": "files = ['VERSION']\n",
+				"VERSION":   tc.version,
+			})
+			initGitRepo(t, dir, tc.gitTag)
+
+			out, _, err := runInDir(t, dir, "check", "--consistency")
+			require.ErrorIs(t, err, tc.expectedError)
+			assert.Contains(t, out, tc.expectedOutput)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/tx3stn/vrsn/internal/config"
+	"github.com/tx3stn/vrsn/internal/output"
+	"github.com/tx3stn/vrsn/internal/version"
+)
+
+// NewCmdCompare creates the compare command.
+func NewCmdCompare(global *globalOptions) *cobra.Command {
+	shortDescription := "Compare the precedence of two semantic versions."
+
+	cmd := &cobra.Command{
+		Args: cobra.ExactArgs(2),
+		RunE: withOutput("compare", global, func(ccmd *cobra.Command, args []string, result *output.Result) error {
+			return runCompare(ccmd, args, global, result)
+		}),
+		//nolint:perfsprint
+		Long: fmt.Sprintf(`%s
+
+Prints lt, eq or gt depending on whether the first version is lower than, equal
+to or greater than the second, e.g.:
+
+  if [ "$(vrsn compare "$current" 2.0.0)" = lt ]; then ...
+
+The v prefix and build metadata are ignored, so v1.2.3 and 1.2.3+build.4 are
+equal, and a pre-release is lower than the version it precedes, so
+1.0.0-alpha is lower than 1.0.0-alpha.1, 1.0.0-beta and 1.0.0.`, shortDescription),
+		Short:         shortDescription,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		Use:           "compare <version> <version>",
+	}
+
+	return cmd
+}
+
+// runCompare is the entrypoint for the compare command.
+func runCompare(ccmd *cobra.Command, args []string, opts *globalOptions, result *output.Result) error {
+	conf, err := config.Get(opts.configFile, opts.flagConfig(), ccmd.Flags())
+	if err != nil {
+		return fmt.Errorf("error getting config: %w", err)
+	}
+
+	log, err := opts.newLogger(ccmd, conf)
+	if err != nil {
+		return err
+	}
+
+	log.Debugf("compare command args: %s", args)
+
+	first, err := version.ParsePrecedence(args[0])
+	if err != nil {
+		return fmt.Errorf("error parsing %s: %w", args[0], err)
+	}
+
+	second, err := version.ParsePrecedence(args[1])
+	if err != nil {
+		return fmt.Errorf("error parsing %s: %w", args[1], err)
+	}
+
+	comparisons := map[int]string{-1: "lt", 0: "eq", 1: "gt"}
+	result.Comparison = comparisons[first.Cmp(second)]
+
+	log.Info(result.Comparison)
+
+	return nil
+}
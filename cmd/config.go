@@ -0,0 +1,349 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/tx3stn/vrsn/internal/config"
+	"github.com/tx3stn/vrsn/internal/files"
+	"github.com/tx3stn/vrsn/internal/output"
+	"github.com/tx3stn/vrsn/internal/prompt"
+)
+
+// configInitOptions are the flag values of the config init command.
+type configInitOptions struct {
+	*globalOptions
+
+	baseBranch string
+	commit     bool
+	files      []string
+	force      bool
+	gitTag     bool
+	yes        bool
+}
+
+// flagConfig returns the config the flag values represent.
+func (o *configInitOptions) flagConfig() config.Config {
+	conf := o.globalOptions.flagConfig()
+	conf.Bump.Commit = o.commit
+	conf.Bump.GitTag = o.gitTag
+	conf.Check.BaseBranch = o.baseBranch
+
+	return conf
+}
+
+// configShowOptions are the flag values of the config show command.
+type configShowOptions struct {
+	*globalOptions
+
+	origin bool
+}
+
+// NewCmdConfig creates the config command, which groups the commands for
+// working with the config files.
+func NewCmdConfig(global *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Short: "Work with the vrsn config files.",
+		Use:   "config",
+	}
+
+	cmd.AddCommand(newCmdConfigInit(global))
+	cmd.AddCommand(newCmdConfigShow(global))
+	cmd.AddCommand(newCmdConfigValidate(global))
+
+	return cmd
+}
+
+// newCmdConfigInit creates the config init command.
+func newCmdConfigInit(global *globalOptions) *cobra.Command {
+	opts := &configInitOptions{globalOptions: global}
+
+	shortDescription := "Create a config file for the project."
+
+	cmd := &cobra.Command{
+		Args: cobra.MaximumNArgs(1),
+		RunE: withOutput("config init", global, func(ccmd *cobra.Command, args []string, result *output.Result) error {
+			return runConfigInit(ccmd, args, opts, result)
+		}),
+		//nolint:perfsprint
+		Long: fmt.Sprintf(`%s
+
+Writes vrsn.toml in the current directory, or the path passed as an argument,
+listing the version files found next to it.
+
+When run in a terminal you are prompted for the options, with the flag values
+as the defaults. Pass --yes to write the flag values without prompting, e.g.:
+
+  vrsn config init --yes --commit --files VERSION,package.json`, shortDescription),
+		Short:         shortDescription,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		Use:           "init [path]",
+	}
+
+	cmd.Flags().
+		StringVar(
+			&opts.baseBranch,
+			"base-branch",
+			config.Default().Check.BaseBranch,
+			"Name of the base branch check compares versions against.",
+		)
+
+	cmd.Flags().
+		BoolVar(&opts.commit, "commit", false, "Commit the version files after a bump.")
+
+	cmd.Flags().
+		StringSliceVar(
+			&opts.files,
+			"files",
+			nil,
+			"Version files to keep in sync, defaults to the version files found.",
+		)
+
+	cmd.Flags().
+		BoolVar(&opts.force, "force", false, "Replace the config file if it already exists.")
+
+	cmd.Flags().
+		BoolVar(&opts.gitTag, "git-tag", false, "Version with git tags rather than version files.")
+
+	cmd.Flags().
+		BoolVar(&opts.yes, "yes", false, "Write the flag values without prompting.")
+
+	return cmd
+}
+
+// runConfigInit is the entrypoint for the config init command.
+func runConfigInit(ccmd *cobra.Command, args []string, opts *configInitOptions, result *output.Result) error {
+	conf, err := config.Get(opts.configFile, opts.flagConfig(), ccmd.Flags())
+	if err != nil {
+		return fmt.Errorf("error getting config: %w", err)
+	}
+
+	log, err := opts.newLogger(ccmd, conf)
+	if err != nil {
+		return err
+	}
+
+	configFile := "vrsn.toml"
+	if len(args) > 0 {
+		configFile = args[0]
+	}
+
+	detected, err := files.GetVersionFilesInDirectory(filepath.Dir(configFile))
+	if err != nil {
+		return fmt.Errorf("error detecting version files: %w", err)
+	}
+
+	log.Debugf("detected version files: %v", detected)
+
+	initOpts := config.InitOptions{
+		BaseBranch: opts.baseBranch,
+		Commit:     opts.commit,
+		Files:      opts.files,
+		GitTag:     opts.gitTag,
+	}
+
+	if !ccmd.Flags().Changed("files") {
+		initOpts.Files = detected
+	}
+
+	if !opts.yes && opts.output != string(output.JSON) && prompt.IsInteractive(ccmd.InOrStdin()) {
+		initOpts, err = prompt.ConfigInit(ccmd.Context(), detected, initOpts)
+		if err != nil {
+			//nolint:wrapcheck
+			return err
+		}
+	}
+
+	content, err := config.Scaffold(initOpts)
+	if err != nil {
+		return fmt.Errorf("error creating config file: %w", err)
+	}
+
+	if err := config.WriteFile(configFile, content, opts.force); err != nil {
+		//nolint:wrapcheck
+		return err
+	}
+
+	result.ConfigFiles = []string{configFile}
+
+	log.Success("created " + configFile)
+
+	return nil
+}
+
+// newCmdConfigShow creates the config show command.
+func newCmdConfigShow(global *globalOptions) *cobra.Command {
+	opts := &configShowOptions{globalOptions: global}
+
+	shortDescription := "Print the effective config."
+
+	cmd := &cobra.Command{
+		Args: cobra.NoArgs,
+		RunE: withOutput("config show", global, func(ccmd *cobra.Command, args []string, result *output.Result) error {
+			return runConfigShow(ccmd, args, opts, result)
+		}),
+		//nolint:perfsprint
+		Long: fmt.Sprintf(`%s
+
+Prints every config option with the value vrsn uses in the current directory,
+after layering the global config file, the project config files and the flags.
+
+Use --origin to also print where each value came from: a config file, a flag or
+the default.`, shortDescription),
+		Short:         shortDescription,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		Use:           "show",
+	}
+
+	cmd.Flags().
+		BoolVar(&opts.origin, "origin", false, "Print where each value came from.")
+
+	return cmd
+}
+
+// runConfigShow is the entrypoint for the config show command.
+func runConfigShow(ccmd *cobra.Command, _ []string, opts *configShowOptions, result *output.Result) error {
+	conf, origins, err := config.Load(opts.configFile, opts.flagConfig(), ccmd.Flags())
+	if err != nil {
+		return fmt.Errorf("error getting config: %w", err)
+	}
+
+	log, err := opts.newLogger(ccmd, conf)
+	if err != nil {
+		return err
+	}
+
+	settings, err := config.Settings(conf)
+	if err != nil {
+		return fmt.Errorf("error listing config options: %w", err)
+	}
+
+	rows := make([][]string, 0, len(settings))
+
+	for _, setting := range settings {
+		shown := output.Setting{Key: setting.Key, Value: setting.Value}
+		row := []string{setting.Key + " = " + setting.Value}
+
+		if opts.origin {
+			shown.Origin = origins.Of(setting.Key)
+			row = append(row, log.Highlight(shown.Origin))
+		}
+
+		result.Settings = append(result.Settings, shown)
+		rows = append(rows, row)
+	}
+
+	log.Table(rows)
+
+	return nil
+}
+
+// newCmdConfigValidate creates the config validate command.
+func newCmdConfigValidate(global *globalOptions) *cobra.Command {
+	shortDescription := "Check the config files for unknown keys and invalid values."
+
+	cmd := &cobra.Command{
+		RunE: withOutput("config validate", global, func(ccmd *cobra.Command, args []string, result *output.Result) error {
+			return runConfigValidate(ccmd, args, global, result)
+		}),
+		//nolint:perfsprint
+		Long: fmt.Sprintf(`%s
+
+Loading the config ignores keys vrsn doesn't know, so a typo such as
+comit = true is silently skipped. Validate reports every unknown key, option
+with the wrong type and invalid value, with the line and column it is on.
+
+Checks the config files that apply to the current directory, the file passed
+with --config, or the files passed as arguments.`, shortDescription),
+		Short:         shortDescription,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		Use:           "validate [file...]",
+	}
+
+	return cmd
+}
+
+// runConfigValidate is the entrypoint for the config validate command.
+func runConfigValidate(ccmd *cobra.Command, args []string, opts *globalOptions, result *output.Result) error {
+	// The config files being validated may not load, so the logger falls back
+	// to the flag values rather than failing before the problems are reported.
+	conf, err := config.Get(opts.configFile, opts.flagConfig(), ccmd.Flags())
+	if err != nil {
+		conf = opts.flagConfig()
+	}
+
+	log, err := opts.newLogger(ccmd, conf)
+	if err != nil {
+		return err
+	}
+
+	configFiles, err := configFilesToValidate(args, opts.configFile)
+	if err != nil {
+		return err
+	}
+
+	if len(configFiles) == 0 {
+		log.Info("no config files found")
+
+		return nil
+	}
+
+	for _, configFile := range configFiles {
+		problems, err := config.ValidateFile(configFile)
+		if err != nil {
+			//nolint:wrapcheck
+			return err
+		}
+
+		result.ConfigFiles = append(result.ConfigFiles, configFile)
+
+		if len(problems) == 0 {
+			log.Success(configFile + " is valid")
+
+			continue
+		}
+
+		for _, problem := range problems {
+			log.Error(problem.String())
+
+			result.Problems = append(result.Problems, output.Problem{
+				File:    problem.File,
+				Line:    problem.Line,
+				Column:  problem.Column,
+				Key:     problem.Key,
+				Message: problem.Message,
+			})
+		}
+	}
+
+	if len(result.Problems) > 0 {
+		return fmt.Errorf("%w: problems found: %d", config.ErrInvalidConfigFile, len(result.Problems))
+	}
+
+	return nil
+}
+
+// configFilesToValidate returns the config files passed as arguments, or with
+// the --config flag, falling back to the config files that apply to the
+// current directory.
+func configFilesToValidate(args []string, configFlag string) ([]string, error) {
+	if len(args) > 0 {
+		return args, nil
+	}
+
+	if configFlag != "" {
+		return []string{configFlag}, nil
+	}
+
+	configFiles, err := config.FindConfigFiles()
+	if err != nil {
+		return nil, fmt.Errorf("error finding config files: %w", err)
+	}
+
+	return configFiles, nil
+}
@@ -1,5 +1,7 @@
 package cmd
 
+import "strconv"
+
 // Error is the error type.
 type Error uint
 
@@ -17,6 +19,21 @@ const (
 	// in a version passed to set contains characters other than letters, digits
 	// and hyphens.
 	ErrInvalidVersionSuffix
+	// ErrNoIncrementType is the error when next is run without an increment
+	// type or the '--all' flag.
+	ErrNoIncrementType
+	// ErrBuildNumberDoesNotMatch is the error when check --consistency finds a
+	// build number that isn't the one derived from the version.
+	ErrBuildNumberDoesNotMatch
+	// ErrConflictingSyncSources is the error when sync is passed more than one
+	// of the '--from', '--from-tag' and '--highest' flags.
+	ErrConflictingSyncSources
+	// ErrNoMajorityVersion is the error when sync is run without a source flag
+	// and no version is in more than half of the version files.
+	ErrNoMajorityVersion
+	// ErrInvalidUsage is the error when the arguments or flags passed to a
+	// command aren't valid, e.g. an unknown flag or too many arguments.
+	ErrInvalidUsage
 )
 
 // Error returns the error string for the error enum.
@@ -34,7 +51,29 @@ func (e Error) Error() string {
 	case ErrInvalidVersionSuffix:
 		return "version suffix must contain only letters, digits and hyphens"
 
+	case ErrNoIncrementType:
+		return "please pass the increment type, one of patch, minor, major or auto, or use the --all flag"
+
+	case ErrBuildNumberDoesNotMatch:
+		return "build number does not match the one derived from the version"
+
+	case ErrConflictingSyncSources:
+		return "please pass only one of --from, --from-tag or --highest"
+
+	case ErrNoMajorityVersion:
+		return "no version is in most of the version files, please pass --from, --from-tag or --highest " +
+			"to pick the version to sync to"
+
+	case ErrInvalidUsage:
+		return "invalid usage"
+
 	default:
 		return "unknown error"
 	}
 }
+
+// Code returns the stable code for the error enum used in machine readable
+// output.
+func (e Error) Code() string {
+	return "cmd." + strconv.FormatUint(uint64(e), 10)
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"errors"
+
+	"github.com/tx3stn/vrsn/internal/buildnumber"
+	"github.com/tx3stn/vrsn/internal/config"
+	"github.com/tx3stn/vrsn/internal/files"
+	"github.com/tx3stn/vrsn/internal/git"
+	"github.com/tx3stn/vrsn/internal/hooks"
+	"github.com/tx3stn/vrsn/internal/logger"
+	"github.com/tx3stn/vrsn/internal/output"
+	"github.com/tx3stn/vrsn/internal/version"
+	"github.com/tx3stn/vrsn/pkg/vrsn"
+)
+
+// Exit codes for each class of failure, so scripts can tell them apart.
+// These are documented in the README and must not change once released.
+const (
+	exitOK                   = 0
+	exitError                = 1
+	exitUsage                = 2
+	exitVersionNotBumped     = 3
+	exitInvalidBump          = 4
+	exitVersionsDoNotMatch   = 5
+	exitMultipleVersionFiles = 6
+	exitFileNotFound         = 7
+	exitVersionNotFound      = 8
+	exitGit                  = 9
+	exitGitNotInstalled      = 10
+	exitConfig               = 11
+	exitNotSatisfied         = 12
+	exitBranchPolicy         = 13
+	exitHookFailed           = 14
+)
+
+// exitCodes maps the typed errors to their exit code, checked in order so the
+// first match in the error chain wins.
+//
+//nolint:gochecknoglobals
+var exitCodes = []struct {
+	err  error
+	code int
+}{
+	// Invalid values from config are config errors, rather than the usage
+	// errors they are when passed as flags.
+	{err: config.ErrInvalidConfigFile, code: exitConfig},
+	{err: config.ErrInvalidEnvVar, code: exitConfig},
+	{err: version.ErrVersionNotBumped, code: exitVersionNotBumped},
+	{err: version.ErrInvalidBump, code: exitInvalidBump},
+	{err: files.ErrVersionsDoNotMatch, code: exitVersionsDoNotMatch},
+	{err: files.ErrVersionOccurrencesDoNotMatch, code: exitVersionsDoNotMatch},
+	{err: ErrBuildNumberDoesNotMatch, code: exitVersionsDoNotMatch},
+	{err: ErrNoMajorityVersion, code: exitVersionsDoNotMatch},
+	{err: files.ErrMultipleVersionFiles, code: exitMultipleVersionFiles},
+	{err: files.ErrNoVersionFilesInDir, code: exitFileNotFound},
+	{err: files.ErrFileNotFound, code: exitFileNotFound},
+	{err: files.ErrFileIsDirectory, code: exitFileNotFound},
+	{err: ErrNoNowOrFile, code: exitFileNotFound},
+	{err: ErrNoWasOrFile, code: exitFileNotFound},
+	{err: git.ErrGitNotInstalled, code: exitGitNotInstalled},
+	{err: vrsn.ErrRejectedByBranchPolicy, code: exitBranchPolicy},
+	{err: hooks.ErrHookFailed, code: exitHookFailed},
+	{err: version.ErrConstraintNotSatisfied, code: exitNotSatisfied},
+	{err: version.ErrInvalidConstraint, code: exitUsage},
+	{err: ErrInvalidUsage, code: exitUsage},
+	{err: ErrInvalidVersionSuffix, code: exitUsage},
+	{err: ErrNoIncrementType, code: exitUsage},
+	{err: ErrConflictingSyncSources, code: exitUsage},
+	{err: ErrCantCompareVersionsOnBranch, code: exitUsage},
+	{err: version.ErrInvalidIncrementType, code: exitUsage},
+	{err: output.ErrInvalidFormat, code: exitUsage},
+	{err: logger.ErrInvalidColorMode, code: exitUsage},
+	{err: logger.ErrInvalidLogFormat, code: exitUsage},
+	{err: logger.ErrInvalidLogLevel, code: exitUsage},
+	{err: buildnumber.ErrInvalidStrategy, code: exitUsage},
+	{err: buildnumber.ErrInvalidDigits, code: exitUsage},
+}
+
+// ExitCode returns the exit code for the error returned by Execute.
+func ExitCode(err error) int {
+	if err == nil {
+		return exitOK
+	}
+
+	for _, mapping := range exitCodes {
+		if errors.Is(err, mapping.err) {
+			return mapping.code
+		}
+	}
+
+	// Remaining errors are mapped by the package they come from.
+	var (
+		filesErr  files.Error
+		gitErr    git.Error
+		configErr config.Error
+	)
+
+	switch {
+	case errors.As(err, &filesErr):
+		return exitVersionNotFound
+	case errors.As(err, &gitErr):
+		return exitGit
+	case errors.As(err, &configErr):
+		return exitConfig
+	default:
+		return exitError
+	}
+}
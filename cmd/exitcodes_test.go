@@ -0,0 +1,75 @@
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tx3stn/vrsn/cmd"
+)
+
+func TestExitCode(t *testing.T) {
+	testCases := map[string]struct {
+		config         string
+		env            map[string]string
+		args           []string
+		expectedCode   int
+		expectedOutput string
+	}{
+		"InvalidValueInConfigFile": {
+			config:         "log-format = 'xml'\n",
+			env:            map[string]string{},
+			args:           []string{"get"},
+			expectedCode:   11,
+			expectedOutput: "",
+		},
+		"InvalidValueInEnvVar": {
+			config:         "",
+			env:            map[string]string{"VRSN_LOG_LEVEL": "loud"},
+			args:           []string{"get"},
+			expectedCode:   11,
+			expectedOutput: "",
+		},
+		"InvalidFlagValue": {
+			config:         "",
+			env:            map[string]string{},
+			args:           []string{"get", "--log-level", "loud"},
+			expectedCode:   2,
+			expectedOutput: "",
+		},
+		"InvalidArgument": {
+			config:         "",
+			env:            map[string]string{},
+			args:           []string{"bump", "bogus", "--output", "json"},
+			expectedCode:   2,
+			expectedOutput: `"message": "invalid usage: invalid argument \"bogus\" for \"vrsn bump\""`,
+		},
+		"UnknownFlag": {
+			config:         "",
+			env:            map[string]string{},
+			args:           []string{"next", "--output", "json", "--bogus"},
+			expectedCode:   2,
+			expectedOutput: `"command": "next"`,
+		},
+		"UnknownCommand": {
+			config:         "",
+			env:            map[string]string{},
+			args:           []string{"bogus"},
+			expectedCode:   2,
+			expectedOutput: "",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			dir := writeProject(t, map[string]string{"vrsn.toml": tc.config, "VERSION": "1.2.3\n"})
+
+			for key, value := range tc.env {
+				t.Setenv(key, value)
+			}
+
+			out, _, err := runInDir(t, dir, tc.args...)
+			assert.Equal(t, tc.expectedCode, cmd.ExitCode(err), err)
+			assert.Contains(t, out, tc.expectedOutput)
+		})
+	}
+}
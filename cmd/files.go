@@ -13,7 +13,7 @@ import (
 func resolveVersionFiles(
 	curDir string,
 	configured []string,
-	log logger.Basic,
+	log logger.Logger,
 	errorOnNoFilesFound bool,
 ) ([]string, error) {
 	finder := files.VersionFileFinder{
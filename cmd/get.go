@@ -1,23 +1,45 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/tx3stn/vrsn/internal/config"
 	"github.com/tx3stn/vrsn/internal/files"
-	"github.com/tx3stn/vrsn/internal/flags"
 	"github.com/tx3stn/vrsn/internal/git"
 	"github.com/tx3stn/vrsn/internal/logger"
+	"github.com/tx3stn/vrsn/internal/output"
 )
 
+// getOptions are the flag values of the get command.
+type getOptions struct {
+	*globalOptions
+
+	buildNumber bool
+	gitTag      bool
+}
+
+// flagConfig returns the config the flag values represent.
+func (o *getOptions) flagConfig() config.Config {
+	conf := o.globalOptions.flagConfig()
+	conf.Get.GitTag = o.gitTag
+
+	return conf
+}
+
 // NewCmdGet creates the get command.
-func NewCmdGet() *cobra.Command {
+func NewCmdGet(global *globalOptions) *cobra.Command {
+	opts := &getOptions{globalOptions: global}
+
 	shortDescription := "Get the current semantic version."
 
 	cmd := &cobra.Command{
-		RunE: runGet,
+		RunE: withOutput("get", global, func(ccmd *cobra.Command, args []string, result *output.Result) error {
+			return runGet(ccmd, args, opts, result)
+		}),
 		//nolint:perfsprint
 		Long: fmt.Sprintf(`%s
 
@@ -31,7 +53,11 @@ use the --file flag to read from a specific file, or the --git-tag flag to read
 the latest git tag.
 
 When multiple files are configured with the files option in the config file the
-version found in each file is printed on a separate line.`, shortDescription),
+version found in each file is printed on a separate line.
+
+Use the --build-number flag to print the build number stored alongside the
+version instead, e.g. android:versionCode in an AndroidManifest file,
+CFBundleVersion in an Info.plist or the +N of the version in a pubspec.yaml.`, shortDescription),
 		Short:         shortDescription,
 		SilenceErrors: true,
 		SilenceUsage:  true,
@@ -40,7 +66,16 @@ version found in each file is printed on a separate line.`, shortDescription),
 
 	cmd.Flags().
 		BoolVar(
-			&flags.GitTag,
+			&opts.buildNumber,
+			"build-number",
+			false,
+			"Read the build number stored alongside the version, e.g. android:versionCode, "+
+				"rather than the version.",
+		)
+
+	cmd.Flags().
+		BoolVar(
+			&opts.gitTag,
 			"git-tag",
 			false,
 			"Read the current version from the latest git tag rather than a version file.",
@@ -50,13 +85,16 @@ version found in each file is printed on a separate line.`, shortDescription),
 }
 
 // runGet is the entrypoint for the get command.
-func runGet(ccmd *cobra.Command, args []string) error {
-	conf, err := config.Get(flags.ConfigFile, ccmd.Flags())
+func runGet(ccmd *cobra.Command, args []string, opts *getOptions, result *output.Result) error {
+	conf, err := config.Get(opts.configFile, opts.flagConfig(), ccmd.Flags())
 	if err != nil {
 		return fmt.Errorf("error getting config: %w", err)
 	}
 
-	log := logger.NewBasic(false, conf.Verbose)
+	log, err := opts.newLogger(ccmd, conf)
+	if err != nil {
+		return err
+	}
 
 	curDir, err := os.Getwd()
 	if err != nil {
@@ -66,14 +104,25 @@ func runGet(ccmd *cobra.Command, args []string) error {
 	log.Debugf("config: %+v", conf)
 	log.Debugf("get command args: %s", args)
 
-	if conf.Bump.GitTag {
-		tag, err := git.LatestTag(curDir)
+	if opts.buildNumber {
+		versionFiles, err := resolveVersionFiles(curDir, conf.Files, log, true)
+		if err != nil {
+			return fmt.Errorf("error locating version file: %w", err)
+		}
+
+		return printBuildNumbersInFiles(curDir, versionFiles, log, result)
+	}
+
+	if conf.Get.GitTag {
+		tag, err := git.LatestTag(ccmd.Context(), curDir)
 		if err != nil {
 			return fmt.Errorf("error getting latest tag: %w", err)
 		}
 
 		log.Info(tag)
 
+		result.Version = tag
+
 		return nil
 	}
 
@@ -82,26 +131,92 @@ func runGet(ccmd *cobra.Command, args []string) error {
 		return fmt.Errorf("error locating version file: %w", err)
 	}
 
-	return printVersionsInFiles(curDir, versionFiles, log)
+	return printVersionsInFiles(curDir, versionFiles, conf.Anchors, log, result)
 }
 
 // printVersionsInFiles prints the version found in the version files.
 // A single file prints the bare version so it can easily be used in scripts,
 // multiple files print a file: version line per file.
-func printVersionsInFiles(curDir string, versionFiles []string, log logger.Basic) error {
+// The result version is only set when every file has the same version.
+func printVersionsInFiles(
+	curDir string,
+	versionFiles []string,
+	anchors map[string][]string,
+	log logger.Logger,
+	result *output.Result,
+) error {
+	versions := make([]files.SourceVersion, 0, len(versionFiles))
+
 	for _, versionFile := range versionFiles {
-		version, err := files.GetVersionFromFile(curDir, versionFile)
+		version, err := files.GetVersionFromFile(
+			curDir,
+			versionFile,
+			files.ReadOptions{Anchors: anchors[versionFile]},
+		)
 		if err != nil {
 			return fmt.Errorf("error getting version from file %s: %w", versionFile, err)
 		}
 
+		result.Files = append(result.Files, output.FileVersion{File: versionFile, Version: version})
+		versions = append(versions, files.SourceVersion{Source: versionFile, Version: version})
+
 		if len(versionFiles) == 1 {
 			log.Info(version)
+		} else {
+			log.Infof("%s: %s", versionFile, version)
+		}
+	}
+
+	if common, err := files.CommonVersion(versions); err == nil {
+		result.Version = common
+	}
+
+	return nil
+}
+
+// printBuildNumbersInFiles prints the build number found in the version files,
+// in the same way as printVersionsInFiles. Files that don't store a build
+// number are skipped when there are others that do.
+func printBuildNumbersInFiles(
+	curDir string,
+	versionFiles []string,
+	log logger.Logger,
+	result *output.Result,
+) error {
+	found := []output.FileVersion{}
+
+	for _, versionFile := range versionFiles {
+		buildNumber, err := files.GetBuildNumberFromFile(curDir, versionFile)
+		if errors.Is(err, files.ErrNoBuildNumber) && len(versionFiles) > 1 {
+			log.Debugf("skipping %s: %s", versionFile, err)
 
-			return nil
+			continue
 		}
 
-		log.Infof("%s: %s", versionFile, version)
+		if err != nil {
+			return fmt.Errorf("error getting build number from file %s: %w", versionFile, err)
+		}
+
+		found = append(found, output.FileVersion{File: versionFile, BuildNumber: buildNumber})
+	}
+
+	if len(found) == 0 {
+		return fmt.Errorf("%w: %s", files.ErrNoBuildNumber, strings.Join(versionFiles, ", "))
+	}
+
+	result.Files = found
+	result.BuildNumber = found[0].BuildNumber
+
+	for _, fileVersion := range found {
+		if len(found) == 1 {
+			log.Info(fileVersion.BuildNumber)
+		} else {
+			log.Infof("%s: %s", fileVersion.File, fileVersion.BuildNumber)
+		}
+
+		if fileVersion.BuildNumber != result.BuildNumber {
+			result.BuildNumber = ""
+		}
 	}
 
 	return nil
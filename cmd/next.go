@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/tx3stn/vrsn/internal/config"
+	"github.com/tx3stn/vrsn/internal/files"
+	"github.com/tx3stn/vrsn/internal/git"
+	"github.com/tx3stn/vrsn/internal/logger"
+	"github.com/tx3stn/vrsn/internal/output"
+	"github.com/tx3stn/vrsn/internal/version"
+)
+
+// nextOptions are the flag values of the next command.
+type nextOptions struct {
+	*globalOptions
+
+	all    bool
+	gitTag bool
+}
+
+// flagConfig returns the config the flag values represent.
+func (o *nextOptions) flagConfig() config.Config {
+	conf := o.globalOptions.flagConfig()
+	conf.Next.GitTag = o.gitTag
+
+	return conf
+}
+
+// NewCmdNext creates the next command.
+func NewCmdNext(global *globalOptions) *cobra.Command {
+	opts := &nextOptions{globalOptions: global}
+
+	shortDescription := "Print the next semantic version without writing anything."
+
+	cmd := &cobra.Command{
+		Args: cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
+		RunE: withOutput("next", global, func(ccmd *cobra.Command, args []string, result *output.Result) error {
+			return runNext(ccmd, args, opts, result)
+		}),
+		//nolint:perfsprint
+		Long: fmt.Sprintf(`%s
+
+Pass the increment type to print the version a bump would create, e.g.:
+
+  tag=$(vrsn next minor)
+
+The current version is read the same way as the get command, from the version
+file in the current directory, the --file flag, the files config option or the
+latest git tag with --git-tag.
+
+Use auto to pick the increment from the Conventional Commits since the version
+last changed: major for breaking changes, minor for feat commits and patch for
+everything else. Use --all to print every candidate.`, shortDescription),
+		Short:         shortDescription,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		Use:           "next <patch|minor|major|auto>",
+		ValidArgs:     []string{"patch", "minor", "major", "auto"},
+	}
+
+	cmd.Flags().
+		BoolVar(&opts.all, "all", false, "Print the version for every increment type.")
+
+	cmd.Flags().
+		BoolVar(
+			&opts.gitTag,
+			"git-tag",
+			false,
+			"Read the current version from the latest git tag rather than a version file.",
+		)
+
+	return cmd
+}
+
+// runNext is the entrypoint for the next command.
+func runNext(ccmd *cobra.Command, args []string, opts *nextOptions, result *output.Result) error {
+	conf, err := config.Get(opts.configFile, opts.flagConfig(), ccmd.Flags())
+	if err != nil {
+		return fmt.Errorf("error getting config: %w", err)
+	}
+
+	log, err := opts.newLogger(ccmd, conf)
+	if err != nil {
+		return err
+	}
+
+	curDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("error getting current working directory: %w", err)
+	}
+
+	log.Debugf("config: %+v", conf)
+	log.Debugf("next command args: %s", args)
+
+	if len(args) == 0 && !opts.all {
+		return ErrNoIncrementType
+	}
+
+	currentVersion, since, err := resolveCurrentVersion(ccmd.Context(), curDir, conf, log)
+	if err != nil {
+		return err
+	}
+
+	result.PreviousVersion = currentVersion
+
+	options, err := version.GetBumpOptions(currentVersion)
+	if err != nil {
+		return fmt.Errorf("error getting bump options: %w", err)
+	}
+
+	if opts.all {
+		result.Candidates = map[string]string{
+			"patch": options.Patch,
+			"minor": options.Minor,
+			"major": options.Major,
+		}
+
+		log.Infof("patch: %s", options.Patch)
+		log.Infof("minor: %s", options.Minor)
+		log.Infof("major: %s", options.Major)
+
+		return nil
+	}
+
+	increment := args[0]
+	if increment == "auto" {
+		increment, err = autoIncrement(ccmd.Context(), curDir, since, log)
+		if err != nil {
+			return err
+		}
+	}
+
+	nextVersion, err := options.SelectedIncrement(increment)
+	if err != nil {
+		return fmt.Errorf("error getting selected increment: %w", err)
+	}
+
+	result.BumpType = increment
+	result.Version = nextVersion
+
+	log.Info(nextVersion)
+
+	return nil
+}
+
+// resolveCurrentVersion reads the current version the same way get does,
+// along with the ref the version was last changed at, used to find the
+// commits since for the auto increment. The ref is empty when the version has
+// never been committed.
+func resolveCurrentVersion(
+	ctx context.Context,
+	curDir string,
+	conf config.Config,
+	log logger.Logger,
+) (string, string, error) {
+	if conf.Next.GitTag {
+		tag, err := git.LatestTag(ctx, curDir)
+		if err != nil {
+			return "", "", fmt.Errorf("error getting latest tag: %w", err)
+		}
+
+		return tag, tag, nil
+	}
+
+	versionFiles, err := resolveVersionFiles(curDir, conf.Files, log, true)
+	if err != nil {
+		return "", "", fmt.Errorf("error locating version file: %w", err)
+	}
+
+	currentVersion, err := files.GetVersionsFromFiles(curDir, versionFiles, conf.Anchors, log)
+	if err != nil {
+		return "", "", fmt.Errorf("error getting version from files: %w", err)
+	}
+
+	return currentVersion, lastVersionCommit(ctx, curDir, versionFiles, log), nil
+}
+
+// lastVersionCommit returns the last commit that changed the version files.
+// Outside of a git repository there is no history to read, which only
+// matters for the auto increment, so the error is logged rather than returned.
+func lastVersionCommit(
+	ctx context.Context,
+	curDir string,
+	versionFiles []string,
+	log logger.Logger,
+) string {
+	sha, err := git.LastCommitTouching(ctx, curDir, versionFiles...)
+	if err != nil {
+		log.Debugf("unable to find last commit to version files: %s", err)
+
+		return ""
+	}
+
+	return sha
+}
+
+// autoIncrement returns the increment type the commits since the ref call
+// for.
+func autoIncrement(
+	ctx context.Context,
+	curDir string,
+	since string,
+	log logger.Logger,
+) (string, error) {
+	messages, err := git.CommitMessagesSince(ctx, curDir, since)
+	if err != nil {
+		return "", fmt.Errorf("error reading commits for auto increment: %w", err)
+	}
+
+	increment := version.IncrementFromCommits(messages)
+
+	log.Debugf("%d commits since %s, auto increment: %s", len(messages), since, increment)
+
+	return increment, nil
+}
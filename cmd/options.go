@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+	"github.com/tx3stn/vrsn/internal/config"
+	"github.com/tx3stn/vrsn/internal/hooks"
+	"github.com/tx3stn/vrsn/internal/logger"
+	"github.com/tx3stn/vrsn/internal/output"
+)
+
+// globalOptions hold the values of the persistent flags shared by every
+// command, and the log file opened for the command that is running.
+// A new set of options is created with each command tree, so commands can run
+// more than once in process.
+type globalOptions struct {
+	color       string
+	configFile  string
+	logFile     string
+	logFormat   string
+	logLevel    string
+	output      string
+	verbose     bool
+	versionFile string
+
+	// closeLog closes the --log-file, set by newLogger when one is opened.
+	closeLog func() error
+	// structured is the logger writing to the --log-file.
+	structured *slog.Logger
+}
+
+// flagConfig returns the config the persistent flag values represent, with the
+// options of the commands at their defaults.
+func (g *globalOptions) flagConfig() config.Config {
+	conf := config.Default()
+	conf.Color = g.color
+	conf.Files = config.FilesFromFlag(g.versionFile)
+	conf.LogFile = g.logFile
+	conf.LogFormat = g.logFormat
+	conf.LogLevel = g.logLevel
+	conf.Verbose = g.verbose
+
+	return conf
+}
+
+// newLogger creates the logger for the command, hiding info logs when the
+// result is written as JSON so stdout only contains the JSON document, and
+// logs the VRSN_* environment variables the config was read from.
+// The structured logger backing it is also added to the command context, so
+// packages without a logger passed to them, e.g. git, log to the same place.
+func (g *globalOptions) newLogger(ccmd *cobra.Command, conf config.Config) (logger.Logger, error) {
+	structured, closeLog, err := logger.NewStructured(
+		logger.StructuredOptions{
+			File:    conf.LogFile,
+			Format:  conf.LogFormat,
+			Level:   conf.LogLevel,
+			Verbose: conf.Verbose,
+		},
+		ccmd.ErrOrStderr(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error creating logger: %w", err)
+	}
+
+	if conf.LogFile != "" {
+		g.closeLog = closeLog
+		g.structured = structured
+	}
+
+	ccmd.SetContext(logger.NewContext(ccmd.Context(), structured))
+
+	log := logger.NewBasic(logger.ColorEnabled(conf.Color, ccmd.OutOrStdout()), structured)
+	log.Quiet = g.output == string(output.JSON)
+	log.Record = conf.LogFile != ""
+	log.Stdout = ccmd.OutOrStdout()
+	log.Stderr = ccmd.ErrOrStderr()
+
+	for _, env := range config.EnvVars() {
+		log.Debugf("config from environment variable: %s", env)
+	}
+
+	return log, nil
+}
+
+// hookRunner creates the runner for the hook commands. Hook output goes to
+// stderr when the result is written as JSON, so stdout only contains the JSON
+// document.
+func (g *globalOptions) hookRunner(ccmd *cobra.Command, dir string) hooks.Runner {
+	stdout := ccmd.OutOrStdout()
+	if g.output == string(output.JSON) {
+		stdout = ccmd.ErrOrStderr()
+	}
+
+	return hooks.Runner{Dir: dir, Stdout: stdout, Stderr: ccmd.ErrOrStderr()}
+}
+
+// closeLogFile records the error the command failed with in the --log-file,
+// so it holds the full output of the command, then closes it.
+func (g *globalOptions) closeLogFile(err error) error {
+	if g.closeLog == nil {
+		return nil
+	}
+
+	if err != nil {
+		g.structured.Error(err.Error())
+	}
+
+	closeErr := g.closeLog()
+	g.closeLog = nil
+
+	if closeErr != nil {
+		return fmt.Errorf("error closing log file: %w", closeErr)
+	}
+
+	return nil
+}
+
+// errorLogger creates the logger used to print the error a command failed
+// with. Color is decided for stderr, which the error is written to, and if the
+// config can't be loaded, e.g. because the error is an invalid config file,
+// the defaults are used.
+func (g *globalOptions) errorLogger(ccmd *cobra.Command) logger.Logger {
+	conf, err := config.Get(g.configFile, g.flagConfig(), ccmd.PersistentFlags())
+	if err != nil {
+		conf = config.Default()
+	}
+
+	log := logger.NewBasic(logger.ColorEnabled(conf.Color, ccmd.ErrOrStderr()), slog.Default())
+	log.Stderr = ccmd.ErrOrStderr()
+
+	return log
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tx3stn/vrsn/internal/output"
+)
+
+// runFunc is a command entrypoint that records what it did in the result so
+// it can be written as machine readable output.
+type runFunc func(ccmd *cobra.Command, args []string, result *output.Result) error
+
+// withOutput wraps the command entrypoint so, when run with --output json, the
+// result is written to stdout as a single JSON document, including any error
+// the command failed with. Any --log-file is closed once the command has run.
+func withOutput(
+	command string,
+	global *globalOptions,
+	run runFunc,
+) func(*cobra.Command, []string) error {
+	return func(ccmd *cobra.Command, args []string) error {
+		format, err := output.ParseFormat(global.output)
+		if err != nil {
+			return err
+		}
+
+		result := output.Result{Command: command}
+
+		err = run(ccmd, args, &result)
+		if closeErr := global.closeLogFile(err); closeErr != nil {
+			err = errors.Join(err, closeErr)
+		}
+
+		if format != output.JSON {
+			return err
+		}
+
+		result.Error = output.NewError(err, ExitCode(err))
+
+		if writeErr := output.Write(ccmd.OutOrStdout(), result); writeErr != nil {
+			return errors.Join(err, writeErr)
+		}
+
+		return err
+	}
+}
+
+// withUsageErrors makes the errors parsing the flags and arguments of the
+// command and its subcommands ErrInvalidUsage errors, written as the result
+// with --output json like the errors the commands fail with, as cobra returns
+// them before the command is run.
+func withUsageErrors(ccmd *cobra.Command, global *globalOptions) {
+	ccmd.SetFlagErrorFunc(func(ccmd *cobra.Command, err error) error {
+		return usageError(ccmd, global, err)
+	})
+
+	commands := []*cobra.Command{ccmd}
+	for len(commands) > 0 {
+		command := commands[0]
+		commands = append(commands[1:], command.Commands()...)
+
+		validateArgs := command.Args
+		if validateArgs == nil {
+			continue
+		}
+
+		command.Args = func(ccmd *cobra.Command, args []string) error {
+			if err := validateArgs(ccmd, args); err != nil {
+				return usageError(ccmd, global, err)
+			}
+
+			return nil
+		}
+	}
+}
+
+// usageError wraps the error parsing the command's flags or arguments in
+// ErrInvalidUsage, writing it as the result with --output json.
+func usageError(ccmd *cobra.Command, global *globalOptions, err error) error {
+	err = fmt.Errorf("%w: %w", ErrInvalidUsage, err)
+
+	// The flags after the invalid one aren't parsed, so --output json only
+	// applies when it comes before it.
+	if format, _ := output.ParseFormat(global.output); format != output.JSON {
+		return err
+	}
+
+	result := output.Result{
+		Command: strings.TrimPrefix(ccmd.CommandPath(), ccmd.Root().Name()+" "),
+		Error:   output.NewError(err, ExitCode(err)),
+	}
+
+	if writeErr := output.Write(ccmd.OutOrStdout(), result); writeErr != nil {
+		return errors.Join(err, writeErr)
+	}
+
+	return err
+}
@@ -6,7 +6,7 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
-	"github.com/tx3stn/vrsn/internal/flags"
+	"github.com/tx3stn/vrsn/internal/config"
 )
 
 // Version is the CLI version set via linker flags at build time.
@@ -14,42 +14,91 @@ import (
 //nolint:gochecknoglobals
 var Version string
 
-//nolint:gochecknoglobals
-var rootCmd = &cobra.Command{
-	RunE: func(ccmd *cobra.Command, args []string) error {
-		err := ccmd.Help()
-		if err != nil {
-			return fmt.Errorf("error getting cobra help: %w", err)
-		}
-
-		return nil
-	},
-	Short:   "A single tool for all of your semantic versioning needs.",
-	Use:     "vrsn",
-	Version: Version,
-}
-
-// Execute executes the root command.
+// Execute executes the root command, printing any error it fails with.
 func Execute() error {
-	ctx := context.Background()
+	rootCmd, global := newRootCmd()
+
+	err := rootCmd.ExecuteContext(context.Background())
+	if err != nil {
+		global.errorLogger(rootCmd).Error(err.Error())
+	}
 
 	//nolint:wrapcheck
-	return rootCmd.ExecuteContext(ctx)
+	return err
 }
 
-//nolint:gochecknoinits
-func init() {
-	rootCmd.AddCommand(NewCmdCheck())
-	rootCmd.AddCommand(NewCmdBump())
-	rootCmd.AddCommand(NewCmdGet())
-	rootCmd.AddCommand(NewCmdSet())
+// NewCmdRoot creates the root command with all of the subcommands. Each call
+// creates a new command tree with its own flag values, so commands can be run
+// in process, e.g. in tests, with the output written to the command's
+// configured writers.
+func NewCmdRoot() *cobra.Command {
+	rootCmd, _ := newRootCmd()
+
+	return rootCmd
+}
+
+func newRootCmd() (*cobra.Command, *globalOptions) {
+	global := &globalOptions{}
+	defaults := config.Default()
+
+	rootCmd := &cobra.Command{
+		RunE: func(ccmd *cobra.Command, args []string) error {
+			err := ccmd.Help()
+			if err != nil {
+				return fmt.Errorf("error getting cobra help: %w", err)
+			}
+
+			return nil
+		},
+		Args:          cobra.NoArgs,
+		Short:         "A single tool for all of your semantic versioning needs.",
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		Use:           "vrsn",
+		Version:       Version,
+	}
+
+	rootCmd.AddCommand(NewCmdCheck(global))
+	rootCmd.AddCommand(NewCmdBump(global))
+	rootCmd.AddCommand(NewCmdCompare(global))
+	rootCmd.AddCommand(NewCmdConfig(global))
+	rootCmd.AddCommand(NewCmdGet(global))
+	rootCmd.AddCommand(NewCmdNext(global))
+	rootCmd.AddCommand(NewCmdSatisfies(global))
+	rootCmd.AddCommand(NewCmdSet(global))
+	rootCmd.AddCommand(NewCmdSort(global))
+	rootCmd.AddCommand(NewCmdSync(global))
+
+	rootCmd.PersistentFlags().
+		BoolVar(&global.verbose, "verbose", false, "display verbose output for more detail on what the command is doing")
+
+	rootCmd.PersistentFlags().
+		StringVar(&global.versionFile, "file", "", "specify the path to the version file (if not in current directory)")
 
 	rootCmd.PersistentFlags().
-		BoolVar(&flags.Verbose, "verbose", false, "display verbose output for more detail on what the command is doing")
+		StringVar(&global.configFile, "config", "", "override the config file location")
 
 	rootCmd.PersistentFlags().
-		StringVar(&flags.VersionFile, "file", "", "specify the path to the version file (if not in current directory)")
+		StringVar(&global.color, "color", defaults.Color, "when to color the output: auto, always or never")
 
 	rootCmd.PersistentFlags().
-		StringVar(&flags.ConfigFile, "config", "", "override the config file location")
+		StringVar(
+			&global.logLevel,
+			"log-level",
+			defaults.LogLevel,
+			"minimum level of log records to write: debug, info, warn or error",
+		)
+
+	rootCmd.PersistentFlags().
+		StringVar(&global.logFormat, "log-format", defaults.LogFormat, "format of log records, text or json")
+
+	rootCmd.PersistentFlags().
+		StringVar(&global.logFile, "log-file", "", "append log records to the file instead of writing them to stderr")
+
+	rootCmd.PersistentFlags().
+		StringVar(&global.output, "output", "text", "output format for the command result, text or json")
+
+	withUsageErrors(rootCmd, global)
+
+	return rootCmd, global
 }
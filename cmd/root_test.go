@@ -0,0 +1,135 @@
+package cmd_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tx3stn/vrsn/cmd"
+	"github.com/tx3stn/vrsn/internal/config"
+	"github.com/tx3stn/vrsn/internal/version"
+)
+
+func TestNewCmdRoot(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		args           []string
+		stdin          string
+		expectedOutput string
+		expectedError  error
+	}{
+		"ChecksValidBump": {
+			args:           []string{"check", "--was", "1.0.0", "--now", "1.1.0"},
+			expectedOutput: "was: 1.0.0\nnow: 1.1.0\nvalid version bump\n",
+			expectedError:  nil,
+		},
+		"ChecksInvalidBump": {
+			args:           []string{"check", "--was", "1.0.0", "--now", "1.0.0"},
+			expectedOutput: "was: 1.0.0\nnow: 1.0.0\n",
+			expectedError:  version.ErrVersionNotBumped,
+		},
+		"ComparesVersions": {
+			args:           []string{"compare", "1.2.0", "1.10.0"},
+			expectedOutput: "lt\n",
+			expectedError:  nil,
+		},
+		"ComparesPrereleaseByPrecedence": {
+			args:           []string{"compare", "1.0.0-alpha", "1.0.0"},
+			expectedOutput: "lt\n",
+			expectedError:  nil,
+		},
+		"ChecksSatisfies": {
+			args:           []string{"satisfies", "1.2.3", "^1.2"},
+			expectedOutput: "1.2.3 satisfies ^1.2\n",
+			expectedError:  nil,
+		},
+		"SortsVersions": {
+			args:           []string{"sort", "--reverse"},
+			stdin:          "1.0.0\n1.10.0\n1.2.0\n",
+			expectedOutput: "1.10.0\n1.2.0\n1.0.0\n",
+			expectedError:  nil,
+		},
+		"SortsPrereleasesByPrecedence": {
+			args:           []string{"sort"},
+			stdin:          "v1.0.0\nv1.0.0-rc.1\nv0.9.0\nv1.0.0-beta.2\n",
+			expectedOutput: "v0.9.0\nv1.0.0-beta.2\nv1.0.0-rc.1\nv1.0.0\n",
+			expectedError:  nil,
+		},
+		"RejectsConflictingSyncSources": {
+			args:           []string{"sync", "--from", "VERSION", "--highest"},
+			expectedOutput: "",
+			expectedError:  cmd.ErrConflictingSyncSources,
+		},
+		"WritesJSONResult": {
+			args:           []string{"compare", "2.0.0", "1.0.0", "--output", "json"},
+			expectedOutput: `"comparison": "gt"`,
+			expectedError:  nil,
+		},
+		"ShowsConfigOrigins": {
+			args:           []string{"config", "show", "--origin", "--log-level", "warn", "--output", "json"},
+			expectedOutput: `"origin": "flag --log-level"`,
+			expectedError:  nil,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// Running the same command twice shows no flag state leaks between
+			// runs.
+			for range 2 {
+				var stdout, stderr bytes.Buffer
+
+				rootCmd := cmd.NewCmdRoot()
+				rootCmd.SetArgs(append(tc.args, "--color", "never"))
+				rootCmd.SetIn(strings.NewReader(tc.stdin))
+				rootCmd.SetOut(&stdout)
+				rootCmd.SetErr(&stderr)
+
+				err := rootCmd.ExecuteContext(t.Context())
+				require.ErrorIs(t, err, tc.expectedError)
+				assert.Contains(t, stdout.String(), tc.expectedOutput)
+			}
+		})
+	}
+}
+
+func TestConfigInitAndValidate(t *testing.T) {
+	t.Parallel()
+
+	configFile := filepath.Join(t.TempDir(), "vrsn.toml")
+
+	run := func(args ...string) (string, error) {
+		var stdout bytes.Buffer
+
+		rootCmd := cmd.NewCmdRoot()
+		rootCmd.SetArgs(append(args, "--color", "never"))
+		rootCmd.SetIn(strings.NewReader(""))
+		rootCmd.SetOut(&stdout)
+		rootCmd.SetErr(&stdout)
+
+		err := rootCmd.ExecuteContext(t.Context())
+
+		return stdout.String(), err
+	}
+
+	out, err := run("config", "init", configFile, "--yes", "--commit", "--files", "VERSION")
+	require.NoError(t, err)
+	assert.Contains(t, out, "created "+configFile)
+
+	_, err = run("config", "init", configFile, "--yes")
+	require.ErrorIs(t, err, config.ErrConfigFileExists)
+
+	out, err = run("config", "validate", configFile)
+	require.NoError(t, err)
+	assert.Contains(t, out, configFile+" is valid")
+
+	out, err = run("config", "validate", "../internal/config/testdata/unknown-keys/vrsn.toml")
+	require.ErrorIs(t, err, config.ErrInvalidConfigFile)
+	assert.Contains(t, out, "vrsn.toml:4:1: unknown key bump.comit")
+}
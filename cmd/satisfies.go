@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/tx3stn/vrsn/internal/config"
+	"github.com/tx3stn/vrsn/internal/output"
+	"github.com/tx3stn/vrsn/internal/version"
+)
+
+// NewCmdSatisfies creates the satisfies command.
+func NewCmdSatisfies(global *globalOptions) *cobra.Command {
+	shortDescription := "Check a semantic version satisfies a version range."
+
+	cmd := &cobra.Command{
+		Args: cobra.ExactArgs(2),
+		RunE: withOutput("satisfies", global, func(ccmd *cobra.Command, args []string, result *output.Result) error {
+			return runSatisfies(ccmd, args, global, result)
+		}),
+		//nolint:perfsprint
+		Long: fmt.Sprintf(`%s
+
+Exits successfully if the version is in the range, e.g.:
+
+  if vrsn satisfies "$version" '^1.2 || ~2.0'; then ...
+
+Ranges use the npm/Cargo syntax: =, >, >=, <, <=, ~ and ^ operators, x-ranges
+such as 1.x or 1.2.*, hyphen ranges such as 1.2.3 - 2.3.4, space or comma
+separated ranges that must all match, and || between alternatives.
+A version without an operator must match exactly. Like npm and Cargo, a
+pre-release, such as 1.2.3-rc.2, only satisfies a range that names a
+pre-release of the same version, such as >=1.2.3-rc.1.`, shortDescription),
+		Short:         shortDescription,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		Use:           "satisfies <version> <range>",
+	}
+
+	return cmd
+}
+
+// runSatisfies is the entrypoint for the satisfies command.
+func runSatisfies(ccmd *cobra.Command, args []string, opts *globalOptions, result *output.Result) error {
+	conf, err := config.Get(opts.configFile, opts.flagConfig(), ccmd.Flags())
+	if err != nil {
+		return fmt.Errorf("error getting config: %w", err)
+	}
+
+	log, err := opts.newLogger(ccmd, conf)
+	if err != nil {
+		return err
+	}
+
+	log.Debugf("satisfies command args: %s", args)
+
+	result.Version = args[0]
+	result.Constraint = args[1]
+
+	satisfied, err := version.Satisfies(args[0], args[1])
+	if err != nil {
+		return fmt.Errorf("error checking version: %w", err)
+	}
+
+	result.Satisfies = &satisfied
+
+	if !satisfied {
+		return fmt.Errorf("%w: %s is not in %s", version.ErrConstraintNotSatisfied, args[0], args[1])
+	}
+
+	log.Infof("%s satisfies %s", args[0], args[1])
+
+	return nil
+}
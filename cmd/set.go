@@ -8,8 +8,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/tx3stn/vrsn/internal/config"
-	"github.com/tx3stn/vrsn/internal/flags"
-	"github.com/tx3stn/vrsn/internal/logger"
+	"github.com/tx3stn/vrsn/internal/output"
 	"github.com/tx3stn/vrsn/internal/version"
 )
 
@@ -19,13 +18,43 @@ import (
 
 var setSuffixRegex = regexp.MustCompile(`^[a-zA-Z0-9-]+$`)
 
+// setOptions are the flag values of the set command.
+type setOptions struct {
+	*globalOptions
+
+	androidVersionCode  bool
+	appleBuildNumber    bool
+	buildNumberStrategy string
+	dryRun              bool
+	pubspecBuildNumber  bool
+}
+
+// flagConfig returns the config the flag values represent.
+func (o *setOptions) flagConfig() config.Config {
+	conf := o.globalOptions.flagConfig()
+	conf.Bump.AndroidVersionCode = o.androidVersionCode
+	conf.Bump.AppleBuildNumber = o.appleBuildNumber
+	conf.Bump.PubspecBuildNumber = o.pubspecBuildNumber
+	conf.BuildNumber.Strategy = o.buildNumberStrategy
+	conf.Set = config.SetOpts{
+		AndroidVersionCode: o.androidVersionCode,
+		AppleBuildNumber:   o.appleBuildNumber,
+		PubspecBuildNumber: o.pubspecBuildNumber,
+	}
+
+	return conf
+}
+
 // NewCmdSet creates the set command.
-func NewCmdSet() *cobra.Command {
+func NewCmdSet(global *globalOptions) *cobra.Command {
+	opts := &setOptions{globalOptions: global}
 	shortDescription := "Set the semantic version in the version file(s) directly."
 
 	cmd := &cobra.Command{
 		Args: cobra.ExactArgs(1),
-		RunE: runSet,
+		RunE: withOutput("set", global, func(ccmd *cobra.Command, args []string, result *output.Result) error {
+			return runSet(ccmd, args, opts, result)
+		}),
 		//nolint:perfsprint
 		Long: fmt.Sprintf(`%s
 
@@ -46,24 +75,62 @@ does not commit or tag.`, shortDescription),
 
 	cmd.Flags().
 		BoolVar(
-			&flags.AndroidVersionCode,
+			&opts.androidVersionCode,
 			"android-version-code",
 			false,
-			"Also set android:versionCode in AndroidManifest files, derived from the "+
-				"version as MAJOR*10000+MINOR*100+PATCH.",
+			"Also set android:versionCode in AndroidManifest files, using the build number "+
+				"strategy.",
+		)
+
+	cmd.Flags().
+		BoolVar(
+			&opts.appleBuildNumber,
+			"apple-build-number",
+			false,
+			"Also set CFBundleVersion in Info.plist and CURRENT_PROJECT_VERSION in "+
+				"project.pbxproj files, using the build number strategy.",
+		)
+
+	cmd.Flags().
+		BoolVar(
+			&opts.pubspecBuildNumber,
+			"pubspec-build-number",
+			false,
+			"Also set the build number after the + of the version in pubspec.yaml files, "+
+				"using the build number strategy.",
+		)
+
+	cmd.Flags().
+		StringVar(
+			&opts.buildNumberStrategy,
+			"build-number-strategy",
+			config.Default().BuildNumber.Strategy,
+			"How build numbers are derived: formula (MAJOR*10000+MINOR*100+PATCH by default), "+
+				"increment, commit-count or timestamp.",
+		)
+
+	cmd.Flags().
+		BoolVar(
+			&opts.dryRun,
+			"dry-run",
+			false,
+			"Show a diff of the version file changes without writing anything.",
 		)
 
 	return cmd
 }
 
 // runSet is the entrypoint for the set command.
-func runSet(ccmd *cobra.Command, args []string) error {
-	conf, err := config.Get(flags.ConfigFile, ccmd.Flags())
+func runSet(ccmd *cobra.Command, args []string, opts *setOptions, result *output.Result) error {
+	conf, err := config.Get(opts.configFile, opts.flagConfig(), ccmd.Flags())
 	if err != nil {
 		return fmt.Errorf("error getting config: %w", err)
 	}
 
-	log := logger.NewBasic(false, conf.Verbose)
+	log, err := opts.newLogger(ccmd, conf)
+	if err != nil {
+		return err
+	}
 
 	curDir, err := os.Getwd()
 	if err != nil {
@@ -73,10 +140,14 @@ func runSet(ccmd *cobra.Command, args []string) error {
 	log.Debugf("config: %+v", conf)
 	log.Debugf("set command args: %s", args)
 
-	return writeVersion(curDir, args, log, conf, writeConfig{
+	return writeVersion(ccmd.Context(), curDir, args, log, conf, result, writeConfig{
+		hooks:              opts.hookRunner(ccmd, curDir),
 		resolve:            getSetVersion,
 		verb:               "set",
 		androidVersionCode: conf.Set.AndroidVersionCode,
+		appleBuildNumber:   conf.Set.AppleBuildNumber,
+		pubspecBuildNumber: conf.Set.PubspecBuildNumber,
+		dryRun:             opts.dryRun,
 	})
 }
 
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tx3stn/vrsn/internal/config"
+	"github.com/tx3stn/vrsn/internal/output"
+	"github.com/tx3stn/vrsn/internal/version"
+)
+
+// sortOptions are the flag values of the sort command.
+type sortOptions struct {
+	*globalOptions
+
+	reverse bool
+}
+
+// NewCmdSort creates the sort command.
+func NewCmdSort(global *globalOptions) *cobra.Command {
+	opts := &sortOptions{globalOptions: global}
+
+	shortDescription := "Sort semantic versions read from stdin by precedence."
+
+	cmd := &cobra.Command{
+		Args: cobra.NoArgs,
+		RunE: withOutput("sort", global, func(ccmd *cobra.Command, args []string, result *output.Result) error {
+			return runSort(ccmd, args, opts, result)
+		}),
+		//nolint:perfsprint
+		Long: fmt.Sprintf(`%s
+
+Reads one version per line from stdin and prints them from lowest to highest,
+comparing each part numerically so 0.0.10 sorts after 0.0.9, and sorting
+pre-releases before the version they precede, e.g.:
+
+  git tag --list | vrsn sort --reverse
+
+Blank lines are skipped, any other line that isn't a valid semantic version is
+an error.`, shortDescription),
+		Short:         shortDescription,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		Use:           "sort",
+	}
+
+	cmd.Flags().
+		BoolVar(&opts.reverse, "reverse", false, "Sort from highest to lowest.")
+
+	return cmd
+}
+
+// runSort is the entrypoint for the sort command.
+func runSort(ccmd *cobra.Command, _ []string, opts *sortOptions, result *output.Result) error {
+	conf, err := config.Get(opts.configFile, opts.flagConfig(), ccmd.Flags())
+	if err != nil {
+		return fmt.Errorf("error getting config: %w", err)
+	}
+
+	log, err := opts.newLogger(ccmd, conf)
+	if err != nil {
+		return err
+	}
+
+	versions := []string{}
+
+	scanner := bufio.NewScanner(ccmd.InOrStdin())
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			versions = append(versions, line)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading versions from stdin: %w", err)
+	}
+
+	log.Debugf("read %d versions from stdin", len(versions))
+
+	sorted, err := version.Sort(versions)
+	if err != nil {
+		return fmt.Errorf("error sorting versions: %w", err)
+	}
+
+	if opts.reverse {
+		slices.Reverse(sorted)
+	}
+
+	result.Versions = sorted
+
+	for _, v := range sorted {
+		log.Info(v)
+	}
+
+	return nil
+}
@@ -0,0 +1,420 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tx3stn/vrsn/internal/config"
+	"github.com/tx3stn/vrsn/internal/diff"
+	"github.com/tx3stn/vrsn/internal/files"
+	"github.com/tx3stn/vrsn/internal/git"
+	"github.com/tx3stn/vrsn/internal/logger"
+	"github.com/tx3stn/vrsn/internal/output"
+	"github.com/tx3stn/vrsn/internal/version"
+)
+
+// syncOptions are the flag values of the sync command.
+type syncOptions struct {
+	*globalOptions
+
+	commit    bool
+	commitMsg string
+	dryRun    bool
+	from      string
+	fromTag   bool
+	highest   bool
+}
+
+// flagConfig returns the config the flag values represent.
+func (o *syncOptions) flagConfig() config.Config {
+	conf := o.globalOptions.flagConfig()
+	conf.Bump.Commit = o.commit
+	conf.Bump.CommitMsg = o.commitMsg
+
+	return conf
+}
+
+// NewCmdSync creates the sync command.
+func NewCmdSync(global *globalOptions) *cobra.Command {
+	opts := &syncOptions{globalOptions: global}
+	shortDescription := "Write the canonical version to every version file that doesn't have it."
+
+	cmd := &cobra.Command{
+		Args: cobra.NoArgs,
+		RunE: withOutput("sync", global, func(ccmd *cobra.Command, args []string, result *output.Result) error {
+			return runSync(ccmd, args, opts, result)
+		}),
+		//nolint:perfsprint
+		Long: fmt.Sprintf(`%s
+
+Brings the version files back in line after only some of them were changed,
+e.g. when package.json was bumped on its own:
+
+  vrsn sync --highest
+
+By default the canonical version is the one more than half of the version
+files have, and sync fails when there isn't one. Use --from to take it from
+a file, --from-tag to take it from the latest git tag or --highest to take the
+highest version in the version files. Only the files with a different version
+are written.`, shortDescription),
+		Short:         shortDescription,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		Use:           "sync",
+	}
+
+	cmd.Flags().
+		StringVar(&opts.from, "from", "", "Take the canonical version from this file.")
+
+	cmd.Flags().
+		BoolVar(&opts.fromTag, "from-tag", false, "Take the canonical version from the latest git tag.")
+
+	cmd.Flags().
+		BoolVar(
+			&opts.highest,
+			"highest",
+			false,
+			"Take the highest version in the version files as the canonical version.",
+		)
+
+	cmd.Flags().
+		BoolVar(&opts.commit, "commit", false, "Commit the updated version files after syncing.")
+
+	cmd.Flags().
+		StringVar(
+			&opts.commitMsg,
+			"commit-msg",
+			"sync version to {{.Version}}",
+			"Customise the commit message used when committing the synced files. "+
+				"Supports Go template syntax, e.g. {{.Version}} for the canonical version.",
+		)
+
+	cmd.Flags().
+		BoolVar(
+			&opts.dryRun,
+			"dry-run",
+			false,
+			"Show a diff of the version file changes without writing or committing anything.",
+		)
+
+	return cmd
+}
+
+// runSync is the entrypoint for the sync command.
+func runSync(ccmd *cobra.Command, args []string, opts *syncOptions, result *output.Result) error {
+	conf, err := config.Get(opts.configFile, opts.flagConfig(), ccmd.Flags())
+	if err != nil {
+		return fmt.Errorf("error getting config: %w", err)
+	}
+
+	log, err := opts.newLogger(ccmd, conf)
+	if err != nil {
+		return err
+	}
+
+	curDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("error getting current working directory: %w", err)
+	}
+
+	log.Debugf("config: %+v", conf)
+	log.Debugf("sync command args: %s", args)
+
+	selected := 0
+
+	for _, set := range []bool{opts.from != "", opts.fromTag, opts.highest} {
+		if set {
+			selected++
+		}
+	}
+
+	if selected > 1 {
+		return ErrConflictingSyncSources
+	}
+
+	versionFiles, err := resolveVersionFiles(curDir, conf.Files, log, true)
+	if err != nil {
+		return fmt.Errorf("error locating version file: %w", err)
+	}
+
+	sources, err := readFileVersions(curDir, versionFiles, conf.Anchors)
+	if err != nil {
+		return err
+	}
+
+	canonical, from, err := canonicalVersion(ccmd.Context(), curDir, sources, conf.Anchors, opts)
+	if err != nil {
+		return err
+	}
+
+	log.Debugf("canonical version %s from %s", canonical, from)
+
+	result.Version = canonical
+	result.DryRun = opts.dryRun
+
+	return syncVersion(ccmd.Context(), curDir, sources, canonical, conf.Anchors, opts, log, result)
+}
+
+// canonicalVersion returns the version to sync the version files to, and
+// where it came from, as picked by the flags.
+func canonicalVersion(
+	ctx context.Context,
+	curDir string,
+	sources []files.SourceVersion,
+	anchors map[string][]string,
+	opts *syncOptions,
+) (string, string, error) {
+	switch {
+	case opts.from != "":
+		canonical, err := files.GetVersionFromFile(curDir, opts.from, files.ReadOptions{Anchors: anchors[opts.from]})
+		if err != nil {
+			return "", "", fmt.Errorf("error getting version from file %s: %w", opts.from, err)
+		}
+
+		return canonical, opts.from, nil
+
+	case opts.fromTag:
+		tag, err := git.LatestTag(ctx, curDir)
+		if err != nil {
+			return "", "", fmt.Errorf("error getting latest tag: %w", err)
+		}
+
+		// Each version file keeps its own v prefix, or lack of one, whatever
+		// the tag has.
+		return strings.TrimPrefix(tag, "v"), "git tag " + tag, nil
+
+	case opts.highest:
+		versions := make([]string, 0, len(sources))
+		for _, source := range sources {
+			versions = append(versions, source.Version)
+		}
+
+		sorted, err := version.Sort(versions)
+		if err != nil {
+			return "", "", fmt.Errorf("error finding the highest version: %w", err)
+		}
+
+		return sorted[len(sorted)-1], "the highest version", nil
+
+	default:
+		return majorityVersion(sources)
+	}
+}
+
+// majorityVersion returns the version more than half of the sources have,
+// ignoring any v prefix. Without one, picking a version could silently revert
+// a bump that was only written to some of the files, so an
+// ErrNoMajorityVersion error listing every source is returned instead.
+func majorityVersion(sources []files.SourceVersion) (string, string, error) {
+	bare := make([]files.SourceVersion, 0, len(sources))
+	for _, source := range sources {
+		bare = append(bare, files.SourceVersion{Source: source.Source, Version: strings.TrimPrefix(source.Version, "v")})
+	}
+
+	canonical, outliers := files.Outliers(bare)
+	if (len(sources)-len(outliers))*2 > len(sources) {
+		return canonical, "most of the version files", nil
+	}
+
+	details := make([]string, 0, len(sources))
+	for _, source := range sources {
+		details = append(details, source.Source+" has "+source.Version)
+	}
+
+	return "", "", fmt.Errorf("%w: %s", ErrNoMajorityVersion, strings.Join(details, ", "))
+}
+
+// syncVersion writes the canonical version to the version files that don't
+// have it, along with their lockfiles, then logs the changes and optionally
+// commits them, recording what it did in the result.
+func syncVersion(
+	ctx context.Context,
+	curDir string,
+	sources []files.SourceVersion,
+	canonical string,
+	anchors map[string][]string,
+	opts *syncOptions,
+	log logger.Logger,
+	result *output.Result,
+) error {
+	previous := map[string]string{}
+	written := map[string]string{}
+	outdated := []string{}
+
+	for _, source := range sources {
+		previous[source.Source] = source.Version
+
+		if target := withPrefixOf(source.Version, canonical); target != source.Version {
+			written[source.Source] = target
+			outdated = append(outdated, source.Source)
+		}
+	}
+
+	if len(outdated) == 0 {
+		log.Success("version files already have " + log.Highlight(canonical))
+
+		return nil
+	}
+
+	// Render the commit message before writing so an invalid template errors
+	// before any files are changed.
+	commitMsg := ""
+	if opts.commit {
+		// The previous version is the one most of the version files had.
+		expected, _ := files.Outliers(sources)
+
+		var err error
+
+		commitMsg, err = renderCommitMsg(ctx, curDir, opts.commitMsg, expected, canonical, outdated)
+		if err != nil {
+			return err
+		}
+	}
+
+	txn := files.Transaction{DryRun: opts.dryRun}
+
+	lockfiles, err := stageSyncedFiles(curDir, outdated, written, anchors, &txn, log)
+	if err != nil {
+		return err
+	}
+
+	result.Files = syncedFileVersions(txn.Changes(), canonical, written, previous, opts.dryRun)
+
+	if opts.dryRun {
+		printSyncDryRun(result.Files, canonical, commitMsg, log)
+
+		return nil
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("error writing version to files: %w", err)
+	}
+
+	if opts.commit {
+		sha, err := commitWrittenFiles(ctx, curDir, &txn, outdated, lockfiles, nil, commitMsg, log)
+		if err != nil {
+			return err
+		}
+
+		result.Commit = sha
+	}
+
+	log.Success("version synced to " + log.Highlight(canonical))
+	printSyncedFiles(result.Files, log)
+
+	if opts.commit {
+		log.Infof("version files committed")
+	}
+
+	return nil
+}
+
+// withPrefixOf returns the version with a v prefix only when the existing
+// version has one, so sync keeps the style of each file as bump does.
+func withPrefixOf(existing string, version string) string {
+	bare := strings.TrimPrefix(version, "v")
+	if strings.HasPrefix(existing, "v") {
+		return "v" + bare
+	}
+
+	return bare
+}
+
+// stageSyncedFiles stages the version written to each of the outdated version
+// files, along with their lockfiles, returning the lockfiles.
+func stageSyncedFiles(
+	curDir string,
+	outdated []string,
+	written map[string]string,
+	anchors map[string][]string,
+	txn *files.Transaction,
+	log logger.Logger,
+) ([]string, error) {
+	lockfiles := []string{}
+
+	for _, versionFile := range outdated {
+		staged, err := stageVersionFiles(
+			curDir, []string{versionFile}, anchors, files.WriteOptions{NewVersion: written[versionFile]}, txn, log,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		lockfiles = append(lockfiles, staged...)
+	}
+
+	return lockfiles, nil
+}
+
+// syncedFileVersions returns the version change of each of the files sync
+// changes, with the diff of the file on a dry run. Lockfiles have no previous
+// version of their own, so they have the canonical version.
+func syncedFileVersions(
+	changes []files.Change,
+	canonical string,
+	written map[string]string,
+	previous map[string]string,
+	dryRun bool,
+) []output.FileVersion {
+	fileResults := make([]output.FileVersion, 0, len(changes))
+
+	for _, change := range changes {
+		// A lockfile can already have the canonical version when only its
+		// manifest was changed.
+		if change.Before == change.After {
+			continue
+		}
+
+		fileResult := output.FileVersion{
+			File:            change.File,
+			Version:         canonical,
+			PreviousVersion: previous[change.File],
+		}
+
+		if version, ok := written[change.File]; ok {
+			fileResult.Version = version
+		}
+
+		if dryRun {
+			fileResult.Diff = diff.Unified(change.File, change.Before, change.After)
+		}
+
+		fileResults = append(fileResults, fileResult)
+	}
+
+	return fileResults
+}
+
+// printSyncedFiles logs a table of the files sync changed, with the version
+// each version file had before. Lockfiles have no version of their own.
+func printSyncedFiles(fileResults []output.FileVersion, log logger.Logger) {
+	rows := make([][]string, 0, len(fileResults))
+
+	for _, fileResult := range fileResults {
+		change := "updated"
+		if fileResult.PreviousVersion != "" {
+			change = fileResult.PreviousVersion + " → " + log.Highlight(fileResult.Version)
+		}
+
+		rows = append(rows, []string{fileResult.File, change})
+	}
+
+	log.Table(rows)
+}
+
+// printSyncDryRun logs the diff of each file sync would change and the commit
+// it would make.
+func printSyncDryRun(fileResults []output.FileVersion, canonical string, commitMsg string, log logger.Logger) {
+	for _, fileResult := range fileResults {
+		log.Info(strings.TrimSuffix(fileResult.Diff, "\n"))
+	}
+
+	log.Infof("dry run: version files would be synced to %s", canonical)
+
+	if commitMsg != "" {
+		log.Infof("dry run: version files would be committed with message: %s", commitMsg)
+	}
+}
@@ -0,0 +1,295 @@
+package cmd_test
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tx3stn/vrsn/cmd"
+)
+
+func TestSync(t *testing.T) {
+	testCases := map[string]struct {
+		files          map[string]string
+		gitTags        []string
+		args           []string
+		expectedOutput []string
+		expectedFiles  map[string]string
+		expectedCommit string
+		expectedError  error
+	}{
+		"SyncsToTheMajorityVersion": {
+			files: map[string]string{
+				"vrsn.toml":      "files = ['VERSION', 'package.json', 'pyproject.toml']\n",
+				"VERSION":        "1.2.0\n",
+				"package.json":   `{"version":"1.3.0"}`,
+				"pyproject.toml": "[project]\nversion = \"1.2.0\"\n",
+			},
+			args:           []string{},
+			expectedOutput: []string{"version synced to 1.2.0\n", "package.json  1.3.0 → 1.2.0\n"},
+			expectedFiles: map[string]string{
+				"VERSION":        "1.2.0\n",
+				"package.json":   `{"version":"1.2.0"}`,
+				"pyproject.toml": "[project]\nversion = \"1.2.0\"\n",
+			},
+			expectedError: nil,
+		},
+		"FailsWithoutAMajorityVersion": {
+			files: map[string]string{
+				"vrsn.toml":    "files = ['VERSION', 'package.json']\n",
+				"VERSION":      "1.2.0\n",
+				"package.json": `{"version":"1.3.0"}`,
+			},
+			args:           []string{},
+			expectedOutput: []string{},
+			expectedFiles: map[string]string{
+				"VERSION":      "1.2.0\n",
+				"package.json": `{"version":"1.3.0"}`,
+			},
+			expectedError: cmd.ErrNoMajorityVersion,
+		},
+		"SyncsToTheVersionInTheFromFile": {
+			files: map[string]string{
+				"vrsn.toml":    "files = ['VERSION', 'package.json']\n",
+				"VERSION":      "1.2.0\n",
+				"package.json": `{"version":"1.3.0"}`,
+			},
+			args:           []string{"--from", "package.json"},
+			expectedOutput: []string{"version synced to 1.3.0\n", "VERSION  1.2.0 → 1.3.0\n"},
+			expectedFiles: map[string]string{
+				"VERSION":      "1.3.0\n",
+				"package.json": `{"version":"1.3.0"}`,
+			},
+			expectedError: nil,
+		},
+		"SyncsToTheLatestTagWithoutVPrefix": {
+			files: map[string]string{
+				"vrsn.toml":    "files = ['VERSION', 'package.json']\n",
+				"VERSION":      "1.2.0\n",
+				"package.json": `{"version":"1.3.0"}`,
+			},
+			gitTags:        []string{"v1.3.0", "v1.4.0"},
+			args:           []string{"--from-tag"},
+			expectedOutput: []string{"version synced to 1.4.0\n"},
+			expectedFiles: map[string]string{
+				"VERSION":      "1.4.0\n",
+				"package.json": `{"version":"1.4.0"}`,
+			},
+			expectedError: nil,
+		},
+		"KeepsTheVPrefixOfEachFile": {
+			files: map[string]string{
+				"vrsn.toml":    "files = ['VERSION', 'package.json']\n",
+				"VERSION":      "v1.2.0\n",
+				"package.json": `{"version":"1.3.0"}`,
+			},
+			gitTags:        []string{"v1.4.0"},
+			args:           []string{"--from-tag"},
+			expectedOutput: []string{"VERSION       v1.2.0 → v1.4.0\n", "package.json  1.3.0 → 1.4.0\n"},
+			expectedFiles: map[string]string{
+				"VERSION":      "v1.4.0\n",
+				"package.json": `{"version":"1.4.0"}`,
+			},
+			expectedError: nil,
+		},
+		"IgnoresTheVPrefixForTheMajorityVersion": {
+			files: map[string]string{
+				"vrsn.toml":      "files = ['VERSION', 'package.json', 'pyproject.toml']\n",
+				"VERSION":        "v1.2.0\n",
+				"package.json":   `{"version":"1.3.0"}`,
+				"pyproject.toml": "[project]\nversion = \"1.2.0\"\n",
+			},
+			args:           []string{},
+			expectedOutput: []string{"version synced to 1.2.0\n", "package.json  1.3.0 → 1.2.0\n"},
+			expectedFiles: map[string]string{
+				"VERSION":        "v1.2.0\n",
+				"package.json":   `{"version":"1.2.0"}`,
+				"pyproject.toml": "[project]\nversion = \"1.2.0\"\n",
+			},
+			expectedError: nil,
+		},
+		"SyncsToTheHighestVersion": {
+			files: map[string]string{
+				"vrsn.toml":      "files = ['VERSION', 'package.json', 'pyproject.toml']\n",
+				"VERSION":        "1.2.0\n",
+				"package.json":   `{"version":"1.10.0"}`,
+				"pyproject.toml": "[project]\nversion = \"1.2.0\"\n",
+			},
+			args:           []string{"--highest"},
+			expectedOutput: []string{"version synced to 1.10.0\n"},
+			expectedFiles: map[string]string{
+				"VERSION":        "1.10.0\n",
+				"package.json":   `{"version":"1.10.0"}`,
+				"pyproject.toml": "[project]\nversion = \"1.10.0\"\n",
+			},
+			expectedError: nil,
+		},
+		"PrintsDiffWithoutWritingOnDryRun": {
+			files: map[string]string{
+				"vrsn.toml":    "files = ['VERSION', 'package.json']\n",
+				"VERSION":      "1.2.0\n",
+				"package.json": `{"version":"1.3.0"}`,
+			},
+			args: []string{"--highest", "--dry-run", "--commit"},
+			expectedOutput: []string{
+				"-1.2.0\n+1.3.0\n",
+				"dry run: version files would be synced to 1.3.0\n",
+				"dry run: version files would be committed with message: sync version to 1.3.0\n",
+			},
+			expectedFiles: map[string]string{
+				"VERSION":      "1.2.0\n",
+				"package.json": `{"version":"1.3.0"}`,
+			},
+			expectedError: nil,
+		},
+		"UpdatesTheLockfileWithItsManifest": {
+			files: map[string]string{
+				"vrsn.toml":         "files = ['VERSION', 'package.json']\n",
+				"VERSION":           "1.3.0\n",
+				"package.json":      `{"name":"app","version":"1.2.0"}`,
+				"package-lock.json": `{"name":"app","version":"1.2.0","packages":{"":{"version":"1.2.0"}}}`,
+			},
+			args:           []string{"--highest"},
+			expectedOutput: []string{"package.json       1.2.0 → 1.3.0\n", "package-lock.json  updated\n"},
+			expectedFiles: map[string]string{
+				"VERSION":           "1.3.0\n",
+				"package.json":      `{"name":"app","version":"1.3.0"}`,
+				"package-lock.json": `{"name":"app","version":"1.3.0","packages":{"":{"version":"1.3.0"}}}`,
+			},
+			expectedError: nil,
+		},
+		"CommitsTheSyncedFiles": {
+			files: map[string]string{
+				"vrsn.toml":    "files = ['VERSION', 'package.json']\n",
+				"VERSION":      "1.2.0\n",
+				"package.json": `{"version":"1.3.0"}`,
+			},
+			gitTags:        []string{},
+			args:           []string{"--highest", "--commit", "--commit-msg", "chore: sync to {{.Version}}"},
+			expectedOutput: []string{"version synced to 1.3.0\n", "version files committed\n"},
+			expectedFiles: map[string]string{
+				"VERSION":      "1.3.0\n",
+				"package.json": `{"version":"1.3.0"}`,
+			},
+			expectedCommit: "chore: sync to 1.3.0",
+			expectedError:  nil,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			dir := writeProject(t, tc.files)
+
+			if tc.gitTags != nil {
+				initGitRepo(t, dir, tc.gitTags...)
+			}
+
+			out, _, err := runInDir(t, dir, append([]string{"sync"}, tc.args...)...)
+			require.ErrorIs(t, err, tc.expectedError)
+
+			for _, expected := range tc.expectedOutput {
+				assert.Contains(t, out, expected)
+			}
+
+			for file, expected := range tc.expectedFiles {
+				actual, err := os.ReadFile(filepath.Join(dir, file))
+				require.NoError(t, err)
+				assert.Equal(t, expected, string(actual), file)
+			}
+
+			if tc.expectedCommit != "" {
+				assert.Equal(t, tc.expectedCommit, runGit(t, dir, "log", "-1", "--format=%s"))
+				assert.Empty(t, runGit(t, dir, "status", "--porcelain"))
+			}
+		})
+	}
+}
+
+func TestSyncWithoutAMajorityListsTheVersionFiles(t *testing.T) {
+	dir := writeProject(t, map[string]string{
+		"vrsn.toml":    "files = ['VERSION', 'package.json']\n",
+		"VERSION":      "1.2.0\n",
+		"package.json": `{"version":"1.3.0"}`,
+	})
+
+	_, _, err := runInDir(t, dir, "sync")
+	require.ErrorIs(t, err, cmd.ErrNoMajorityVersion)
+	assert.ErrorContains(t, err, "VERSION has 1.2.0, package.json has 1.3.0")
+	assert.ErrorContains(t, err, "--from, --from-tag or --highest")
+}
+
+// writeProject writes the files to a new temporary directory and returns it.
+func writeProject(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	for file, contents := range files {
+		path := filepath.Join(dir, file)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+		require.NoError(t, os.WriteFile(path, []byte(contents), 0o600))
+	}
+
+	return dir
+}
+
+// initGitRepo makes the directory a git repository with its files committed
+// and the tags on that commit.
+func initGitRepo(t *testing.T, dir string, tags ...string) {
+	t.Helper()
+
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	t.Setenv("GIT_AUTHOR_NAME", "vrsn")
+	t.Setenv("GIT_AUTHOR_EMAIL", "vrsn@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "vrsn")
+	t.Setenv("GIT_COMMITTER_EMAIL", "vrsn@example.com")
+
+	runGit(t, dir, "init", "--quiet", "--initial-branch", "main")
+	runGit(t, dir, "add", ".")
+	runGit(t, dir, "commit", "--quiet", "--message", "initial commit")
+
+	for _, tag := range tags {
+		runGit(t, dir, "tag", tag)
+	}
+}
+
+// runGit runs the git command in the directory and returns its trimmed output.
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+
+	gitCmd := exec.CommandContext(t.Context(), "git", args...)
+	gitCmd.Dir = dir
+
+	out, err := gitCmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	return strings.TrimSpace(string(out))
+}
+
+// runInDir runs vrsn with the args in the directory, using the vrsn.toml
+// config file there and no global config, and returns what it wrote to stdout
+// and stderr.
+func runInDir(t *testing.T, dir string, args ...string) (string, string, error) {
+	t.Helper()
+
+	t.Chdir(dir)
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	var stdout, stderr bytes.Buffer
+
+	rootCmd := cmd.NewCmdRoot()
+	rootCmd.SetArgs(append(args, "--color", "never", "--config", "vrsn.toml"))
+	rootCmd.SetIn(strings.NewReader(""))
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&stderr)
+
+	err := rootCmd.ExecuteContext(t.Context())
+
+	return stdout.String(), stderr.String(), err
+}
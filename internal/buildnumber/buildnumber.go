@@ -0,0 +1,114 @@
+// Package buildnumber derives the build numbers written alongside the version,
+// such as android:versionCode, using the configured strategy.
+package buildnumber
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tx3stn/vrsn/internal/files"
+	"github.com/tx3stn/vrsn/internal/version"
+)
+
+const (
+	// Formula derives the build number from the new version, see
+	// version.SemVer.BuildNumber.
+	Formula = "formula"
+	// Increment adds one to the build number currently in each file.
+	Increment = "increment"
+	// CommitCount uses the number of commits in the history of HEAD.
+	CommitCount = "commit-count"
+	// Timestamp uses the Unix time in seconds.
+	Timestamp = "timestamp"
+)
+
+// DefaultDigits is the digits the Formula strategy reserves for the minor and
+// patch versions by default, so 1.2.3 is 10203.
+const DefaultDigits = 2
+
+// maxDigits is the most digits the formula can reserve for the minor or patch
+// version, as a build number with more would overflow a 32 bit integer.
+const maxDigits = 9
+
+// Options configure how build numbers are derived.
+type Options struct {
+	// Strategy is one of Formula, Increment, CommitCount or Timestamp.
+	Strategy string
+	// MinorDigits and PatchDigits are the digits the Formula strategy reserves
+	// for the minor and patch versions.
+	MinorDigits int
+	PatchDigits int
+	// CommitCount returns the number of commits for the CommitCount strategy,
+	// it is only called when that strategy is used.
+	CommitCount func() (int, error)
+	// Now is the time used by the Timestamp strategy.
+	Now time.Time
+}
+
+// Validate returns an error if the strategy isn't supported or the formula
+// digits are out of range.
+func Validate(strategy string, minorDigits int, patchDigits int) error {
+	if !slices.Contains([]string{Formula, Increment, CommitCount, Timestamp}, strategy) {
+		return fmt.Errorf("%w: %s", ErrInvalidStrategy, strategy)
+	}
+
+	for _, digits := range []int{minorDigits, patchDigits} {
+		if digits < 1 || digits > maxDigits {
+			return fmt.Errorf("%w: %d", ErrInvalidDigits, digits)
+		}
+	}
+
+	return nil
+}
+
+// New returns the build number to write alongside the new version. The same
+// number is written to every file, other than with the Increment strategy
+// which increments the build number of each file.
+func New(newVersion string, opts Options) (files.BuildNumber, error) {
+	if err := Validate(opts.Strategy, opts.MinorDigits, opts.PatchDigits); err != nil {
+		return nil, err
+	}
+
+	switch opts.Strategy {
+	case Increment:
+		return increment, nil
+
+	case CommitCount:
+		count, err := opts.CommitCount()
+		if err != nil {
+			return nil, fmt.Errorf("error counting commits for build number: %w", err)
+		}
+
+		return files.FixedBuildNumber(strconv.Itoa(count)), nil
+
+	case Timestamp:
+		return files.FixedBuildNumber(strconv.FormatInt(opts.Now.Unix(), 10)), nil
+
+	default:
+		code, err := version.NumericCode(newVersion, opts.MinorDigits, opts.PatchDigits)
+		if err != nil {
+			return nil, fmt.Errorf("error deriving build number from version: %w", err)
+		}
+
+		return files.FixedBuildNumber(code), nil
+	}
+}
+
+// increment adds one to the last part of the current build number, so a
+// dotted Apple build number such as 1.2.7 becomes 1.2.8.
+func increment(current string) (string, error) {
+	head, last := "", current
+	if index := strings.LastIndex(current, "."); index >= 0 {
+		head, last = current[:index+1], current[index+1:]
+	}
+
+	number, err := strconv.Atoi(last)
+	if err != nil {
+		return "", fmt.Errorf("%w: %q", ErrIncrementingBuildNumber, current)
+	}
+
+	return head + strconv.Itoa(number+1), nil
+}
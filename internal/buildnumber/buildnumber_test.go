@@ -0,0 +1,131 @@
+package buildnumber_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tx3stn/vrsn/internal/buildnumber"
+	"github.com/tx3stn/vrsn/internal/version"
+)
+
+var errNoRepository = errors.New("not a git repository")
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	commitCount := func() (int, error) { return 42, nil }
+
+	testCases := map[string]struct {
+		newVersion    string
+		opts          buildnumber.Options
+		current       string
+		expected      string
+		expectedError error
+	}{
+		"DerivesFormulaFromVersion": {
+			newVersion:    "1.2.3",
+			opts:          buildnumber.Options{Strategy: buildnumber.Formula, MinorDigits: 2, PatchDigits: 2},
+			current:       "10202",
+			expected:      "10203",
+			expectedError: nil,
+		},
+		"DerivesFormulaWithConfiguredDigits": {
+			newVersion:    "1.2.300",
+			opts:          buildnumber.Options{Strategy: buildnumber.Formula, MinorDigits: 3, PatchDigits: 3},
+			current:       "",
+			expected:      "1002300",
+			expectedError: nil,
+		},
+		"ErrorsWhenFormulaOverflows": {
+			newVersion:    "1.2.100",
+			opts:          buildnumber.Options{Strategy: buildnumber.Formula, MinorDigits: 2, PatchDigits: 2},
+			current:       "",
+			expected:      "",
+			expectedError: version.ErrVersionPartTooLarge,
+		},
+		"IncrementsCurrentBuildNumber": {
+			newVersion:    "1.2.3",
+			opts:          buildnumber.Options{Strategy: buildnumber.Increment, MinorDigits: 2, PatchDigits: 2},
+			current:       "41",
+			expected:      "42",
+			expectedError: nil,
+		},
+		"IncrementsLastPartOfDottedBuildNumber": {
+			newVersion:    "1.2.3",
+			opts:          buildnumber.Options{Strategy: buildnumber.Increment, MinorDigits: 2, PatchDigits: 2},
+			current:       "1.2.9",
+			expected:      "1.2.10",
+			expectedError: nil,
+		},
+		"ErrorsIncrementingNonNumericBuildNumber": {
+			newVersion:    "1.2.3",
+			opts:          buildnumber.Options{Strategy: buildnumber.Increment, MinorDigits: 2, PatchDigits: 2},
+			current:       "abc",
+			expected:      "",
+			expectedError: buildnumber.ErrIncrementingBuildNumber,
+		},
+		"UsesCommitCount": {
+			newVersion: "1.2.3",
+			opts: buildnumber.Options{
+				Strategy: buildnumber.CommitCount, MinorDigits: 2, PatchDigits: 2, CommitCount: commitCount,
+			},
+			current:       "7",
+			expected:      "42",
+			expectedError: nil,
+		},
+		"ErrorsWhenCommitsCannotBeCounted": {
+			newVersion: "1.2.3",
+			opts: buildnumber.Options{
+				Strategy:    buildnumber.CommitCount,
+				MinorDigits: 2,
+				PatchDigits: 2,
+				CommitCount: func() (int, error) { return 0, errNoRepository },
+			},
+			current:       "",
+			expected:      "",
+			expectedError: errNoRepository,
+		},
+		"UsesTimestamp": {
+			newVersion: "1.2.3",
+			opts: buildnumber.Options{
+				Strategy: buildnumber.Timestamp, MinorDigits: 2, PatchDigits: 2, Now: time.Unix(1760000000, 0),
+			},
+			current:       "",
+			expected:      "1760000000",
+			expectedError: nil,
+		},
+		"ErrorsForUnknownStrategy": {
+			newVersion:    "1.2.3",
+			opts:          buildnumber.Options{Strategy: "random", MinorDigits: 2, PatchDigits: 2},
+			current:       "",
+			expected:      "",
+			expectedError: buildnumber.ErrInvalidStrategy,
+		},
+		"ErrorsForInvalidDigits": {
+			newVersion:    "1.2.3",
+			opts:          buildnumber.Options{Strategy: buildnumber.Formula, MinorDigits: 0, PatchDigits: 2},
+			current:       "",
+			expected:      "",
+			expectedError: buildnumber.ErrInvalidDigits,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			buildNumber, err := buildnumber.New(tc.newVersion, tc.opts)
+			if err == nil {
+				var actual string
+
+				actual, err = buildNumber(tc.current)
+				assert.Equal(t, tc.expected, actual)
+			}
+
+			require.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+}
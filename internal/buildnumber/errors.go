@@ -0,0 +1,41 @@
+package buildnumber
+
+import "strconv"
+
+// Error is the error type.
+type Error uint
+
+const (
+	// ErrInvalidStrategy is the error when the build number strategy isn't
+	// one of the supported strategies.
+	ErrInvalidStrategy Error = iota + 1
+	// ErrInvalidDigits is the error when the formula strategy is configured
+	// with fewer than one digit for the minor or patch version.
+	ErrInvalidDigits
+	// ErrIncrementingBuildNumber is the error when the increment strategy
+	// can't increment the current build number because it isn't a number.
+	ErrIncrementingBuildNumber
+)
+
+// Error returns the error string for the error enum.
+func (e Error) Error() string {
+	switch e {
+	case ErrInvalidStrategy:
+		return "invalid build number strategy, must be one of: formula, increment, commit-count, timestamp"
+
+	case ErrInvalidDigits:
+		return "build number minor and patch digits must be between 1 and 9"
+
+	case ErrIncrementingBuildNumber:
+		return "unable to increment the current build number"
+
+	default:
+		return "unknown error"
+	}
+}
+
+// Code returns the stable code for the error enum used in machine readable
+// output.
+func (e Error) Code() string {
+	return "buildnumber." + strconv.FormatUint(uint64(e), 10)
+}
@@ -3,11 +3,17 @@ package config
 
 import (
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/pelletier/go-toml/v2"
-	"github.com/tx3stn/vrsn/internal/flags"
+	"github.com/tx3stn/vrsn/internal/buildnumber"
+	"github.com/tx3stn/vrsn/internal/logger"
+	"github.com/tx3stn/vrsn/internal/version"
+	"github.com/tx3stn/vrsn/pkg/vrsn"
 )
 
 // FlagChecker reports whether a flag was explicitly set on the command line.
@@ -20,169 +26,498 @@ type FlagChecker interface {
 type (
 	// Config represents the options available in the config file.
 	Config struct {
-		Bump    BumpOpts  `toml:"bump"`
-		Check   CheckOpts `toml:"check"`
-		Set     SetOpts   `toml:"set"`
-		Files   []string  `toml:"files"`
-		Verbose bool      `toml:"verbose"`
+		Anchors     map[string][]string `toml:"anchors"`
+		Bump        BumpOpts            `toml:"bump"`
+		BuildNumber BuildNumberOpts     `toml:"build-number"`
+		Check       CheckOpts           `toml:"check"`
+		Color       string              `toml:"color"`
+		Get         GetOpts             `toml:"get"`
+		Hooks       HooksOpts           `toml:"hooks"`
+		Next        NextOpts            `toml:"next"`
+		Set         SetOpts             `toml:"set"`
+		Files       []string            `toml:"files"`
+		LogFile     string              `toml:"log-file"`
+		LogFormat   string              `toml:"log-format"`
+		LogLevel    string              `toml:"log-level"`
+		Verbose     bool                `toml:"verbose"`
 	}
 
 	// BumpOpts are the vrsn bump specific options in the config file.
 	BumpOpts struct {
 		AndroidVersionCode bool   `toml:"android-version-code"`
+		AppleBuildNumber   bool   `toml:"apple-build-number"`
 		Commit             bool   `toml:"commit"`
 		CommitMsg          string `toml:"commit-msg"`
 		GitTag             bool   `toml:"git-tag"`
+		PubspecBuildNumber bool   `toml:"pubspec-build-number"`
 		TagMsg             string `toml:"tag-msg"`
+		TagName            string `toml:"tag-name"`
+	}
+
+	// BuildNumberOpts configure how the build numbers written with the
+	// android-version-code, apple-build-number and pubspec-build-number
+	// options are derived, see the buildnumber package.
+	BuildNumberOpts struct {
+		MinorDigits int    `toml:"minor-digits"`
+		PatchDigits int    `toml:"patch-digits"`
+		Strategy    string `toml:"strategy"`
 	}
 
 	// CheckOpts are the vrsn check specific options in the config file.
 	CheckOpts struct {
-		BaseBranch string `toml:"base-branch"`
+		BaseBranch string                  `toml:"base-branch"`
+		Branches   map[string]BranchPolicy `toml:"branches"`
+		MaxVersion string                  `toml:"max-version"`
+		Range      string                  `toml:"range"`
+	}
+
+	// GetOpts are the vrsn get specific options in the config file.
+	GetOpts struct {
+		GitTag bool `toml:"git-tag"`
+	}
+
+	// NextOpts are the vrsn next specific options in the config file.
+	NextOpts struct {
+		GitTag bool `toml:"git-tag"`
+	}
+
+	// HooksOpts are the commands run at each stage of writing a new version
+	// with bump or set, see the hooks package.
+	HooksOpts struct {
+		// CommitFiles are files the hooks change, e.g. lockfiles, to commit
+		// with the version files. They are restored along with the version
+		// files if a hook fails.
+		CommitFiles []string `toml:"commit-files"`
+		PostTag     []string `toml:"post-tag"`
+		PostWrite   []string `toml:"post-write"`
+		PreBump     []string `toml:"pre-bump"`
+		PreCommit   []string `toml:"pre-commit"`
 	}
 
+	// BranchPolicy restricts the version changes check accepts on the branches
+	// matching the pattern it is keyed by in CheckOpts.Branches.
+	BranchPolicy = vrsn.BranchPolicy
+
 	// SetOpts are the vrsn set specific options in the config file.
 	SetOpts struct {
 		AndroidVersionCode bool `toml:"android-version-code"`
+		AppleBuildNumber   bool `toml:"apple-build-number"`
+		PubspecBuildNumber bool `toml:"pubspec-build-number"`
 	}
 )
 
-// Get returns the effective config: values from a config file (if one is
-// found) layered over the CLI flag defaults, with any flags explicitly passed
-// on the command line taking precedence over the config file.
-// The one exception is the documented behaviour that `files` in the config
-// file takes precedence over the --file flag.
-func Get(fileFlag string, flagSet FlagChecker) (Config, error) {
-	conf := Config{
+// Default returns the config used when no flags are passed and no config file
+// is found. The CLI flags use the same defaults.
+func Default() Config {
+	return Config{
 		Bump: BumpOpts{
-			AndroidVersionCode: flags.AndroidVersionCode,
-			Commit:             flags.Commit,
-			CommitMsg:          flags.CommitMsg,
-			GitTag:             flags.GitTag,
-			TagMsg:             flags.TagMsg,
+			CommitMsg: "bump version",
 		},
-		Check: CheckOpts{
-			BaseBranch: flags.BaseBranch,
+		BuildNumber: BuildNumberOpts{
+			MinorDigits: buildnumber.DefaultDigits,
+			PatchDigits: buildnumber.DefaultDigits,
+			Strategy:    buildnumber.Formula,
 		},
-		Set: SetOpts{
-			AndroidVersionCode: flags.AndroidVersionCode,
+		Check: CheckOpts{
+			BaseBranch: "main",
 		},
-		Files:   filesFromFlag(flags.VersionFile),
-		Verbose: flags.Verbose,
+		Color:     logger.ColorAuto,
+		LogFormat: logger.FormatText,
+		LogLevel:  "info",
 	}
+}
 
-	file := fileFlag
-	if file == "" {
+// FilesFromFlag converts the --file flag value into the config files list.
+func FilesFromFlag(versionFile string) []string {
+	if versionFile == "" {
+		return nil
+	}
+
+	return []string{versionFile}
+}
+
+// Get returns the effective config, see Load.
+func Get(fileFlag string, flagConf Config, flagSet FlagChecker) (Config, error) {
+	conf, _, err := Load(fileFlag, flagConf, flagSet)
+
+	return conf, err
+}
+
+// Load returns the effective config along with where each of its values came
+// from. The config is built up in layers, each overriding the individual
+// options it sets in the layers before it:
+//   - the flag values in flagConf, which hold the value of every flag of the
+//     command, including defaults for the ones that weren't passed, and the
+//     options of other commands at their defaults, see Default
+//   - the config files found by FindConfigFiles, or only the file passed with
+//     the --config flag
+//   - VRSN_* environment variables, see EnvVarName
+//   - flags explicitly passed on the command line
+//
+// The one exception is the documented behaviour that `files` in a config file,
+// or VRSN_FILES, takes precedence over the --file flag.
+func Load(fileFlag string, flagConf Config, flagSet FlagChecker) (Config, Origins, error) {
+	conf := flagConf
+	origins := Origins{}
+
+	global, project := fileFlag, []string{}
+	if fileFlag == "" {
 		var err error
 
-		file, err = FindConfigFile()
+		global, project, err = findConfigFiles()
 		if err != nil {
-			return Config{}, err
+			return Config{}, nil, err
 		}
 	}
 
-	if file == "" {
-		return conf, nil
+	// The global config file and the one passed with --config aren't tied to
+	// a project directory, so their paths stay relative to the current
+	// directory.
+	if global != "" {
+		if err := loadFile(global, "", &conf, origins); err != nil {
+			return Config{}, nil, err
+		}
+	}
+
+	for _, file := range project {
+		if err := loadFile(file, filepath.Dir(file), &conf, origins); err != nil {
+			return Config{}, nil, err
+		}
 	}
 
+	if err := applyEnv(&conf, origins); err != nil {
+		return Config{}, nil, err
+	}
+
+	if flagSet != nil && flagSet.Changed("file") {
+		if _, ok := origins["files"]; !ok {
+			origins["files"] = flagOrigin("file")
+		}
+	}
+
+	applyChangedFlags(&conf, flagConf, flagSet, origins)
+
+	conf, err := validate(conf, origins)
+	if err != nil {
+		return Config{}, nil, err
+	}
+
+	return conf, origins, nil
+}
+
+// loadFile unmarshals the config file over the config, so options missing from
+// the file keep their current value, and records the file as the origin of the
+// options it sets.
+// The relative paths in the files and anchors options the file sets are
+// rewritten to be relative to the current directory rather than dir, the
+// directory the file is in, when dir isn't empty.
+func loadFile(file string, dir string, conf *Config, origins Origins) error {
 	content, err := os.ReadFile(filepath.Clean(file))
 	if err != nil {
-		return Config{}, fmt.Errorf("error reading config file: %w", err)
+		return fmt.Errorf("%w: %w", ErrReadingConfigFile, err)
 	}
 
-	// Unmarshalling over the flag based config means options missing from the
-	// config file keep the flag default, while `files` from the config file
-	// still replaces the --file flag value.
-	if err = toml.Unmarshal(content, &conf); err != nil {
-		return Config{}, fmt.Errorf("error unmarshalling config from file: %w", err)
+	anchors := maps.Clone(conf.Anchors)
+
+	if err = toml.Unmarshal(content, conf); err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrParsingConfigFile, file, err)
 	}
 
-	applyChangedFlags(&conf, flagSet)
+	table := map[string]any{}
+	if err = toml.Unmarshal(content, &table); err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrParsingConfigFile, file, err)
+	}
 
-	return conf, nil
+	if _, ok := table["files"]; ok {
+		for i, versionFile := range conf.Files {
+			conf.Files[i] = relativeTo(dir, versionFile)
+		}
+	}
+
+	// The anchors of the earlier layers are restored first, as unmarshalling
+	// overwrites any of them keyed by the same path relative to another
+	// directory.
+	fileAnchors, _ := table["anchors"].(map[string]any)
+	if len(fileAnchors) > 0 {
+		if anchors == nil {
+			anchors = map[string][]string{}
+		}
+
+		rebased := make(map[string]any, len(fileAnchors))
+
+		for versionFile, value := range fileAnchors {
+			path := relativeTo(dir, versionFile)
+			anchors[path] = conf.Anchors[versionFile]
+			rebased[path] = value
+		}
+
+		conf.Anchors = anchors
+		table["anchors"] = rebased
+	}
+
+	for key := range flatten("", table) {
+		origins[key] = file
+	}
+
+	return nil
 }
 
-// applyChangedFlags overrides config file values with any flags that were
-// explicitly set on the command line, so passing a flag always works
-// regardless of which config file is found.
-// Flags not registered on the current command report as unchanged.
-func applyChangedFlags(conf *Config, flagSet FlagChecker) {
-	if flagSet == nil {
-		return
+// relativeTo returns the path, when it is relative to dir, relative to the
+// current directory instead. dir is relative to the current directory, or
+// empty when the path already is.
+func relativeTo(dir string, path string) string {
+	if dir == "" || dir == "." || filepath.IsAbs(path) {
+		return path
 	}
 
-	if flagSet.Changed("android-version-code") {
-		conf.Bump.AndroidVersionCode = flags.AndroidVersionCode
-		conf.Set.AndroidVersionCode = flags.AndroidVersionCode
+	return filepath.Join(dir, path)
+}
+
+// validate checks the options that can't be checked by their type alone,
+// returning the config when they are all valid. An invalid value set by a
+// config file or environment variable is wrapped in ErrInvalidConfigFile or
+// ErrInvalidEnvVar naming where it came from, so it isn't mistaken for an
+// invalid flag.
+func validate(conf Config, origins Origins) (Config, error) {
+	invalid := invalidOptions(conf)
+	if len(invalid) == 0 {
+		return conf, nil
 	}
 
-	if flagSet.Changed("commit") {
-		conf.Bump.Commit = flags.Commit
+	origin := origins.configOrigin(invalid[0].key)
+
+	switch {
+	case origin == "":
+		return Config{}, fmt.Errorf("error validating config: %w", invalid[0].err)
+
+	case strings.HasPrefix(origin, envOrigin("")):
+		return Config{}, fmt.Errorf(
+			"error validating config: %w: %s: %w",
+			ErrInvalidEnvVar, strings.TrimPrefix(origin, envOrigin("")), invalid[0].err,
+		)
+
+	default:
+		return Config{}, fmt.Errorf("error validating config: %w: %s: %w", ErrInvalidConfigFile, origin, invalid[0].err)
 	}
+}
 
-	if flagSet.Changed("commit-msg") {
-		conf.Bump.CommitMsg = flags.CommitMsg
+// invalidOption is a config option with a value that isn't valid.
+type invalidOption struct {
+	key string
+	err error
+}
+
+// invalidOptions returns every option of the config with an invalid value
+// that can't be caught by its type alone, e.g. an unknown color mode or a
+// version range that can't be parsed.
+func invalidOptions(conf Config) []invalidOption {
+	invalid := []invalidOption{}
+
+	if err := logger.ValidateColorMode(conf.Color); err != nil {
+		invalid = append(invalid, invalidOption{key: "color", err: err})
 	}
 
-	if flagSet.Changed("git-tag") {
-		conf.Bump.GitTag = flags.GitTag
+	if err := logger.ValidateLogFormat(conf.LogFormat); err != nil {
+		invalid = append(invalid, invalidOption{key: "log-format", err: err})
 	}
 
-	if flagSet.Changed("tag-msg") {
-		conf.Bump.TagMsg = flags.TagMsg
+	if _, err := logger.ParseLevel(conf.LogLevel); err != nil {
+		invalid = append(invalid, invalidOption{key: "log-level", err: err})
 	}
 
-	if flagSet.Changed("base-branch") {
-		conf.Check.BaseBranch = flags.BaseBranch
+	if err := buildnumber.Validate(
+		conf.BuildNumber.Strategy,
+		conf.BuildNumber.MinorDigits,
+		conf.BuildNumber.PatchDigits,
+	); err != nil {
+		invalid = append(invalid, invalidOption{key: "build-number", err: err})
 	}
 
-	if flagSet.Changed("verbose") {
-		conf.Verbose = flags.Verbose
+	if err := validateRange("check.range", conf.Check.Range); err != nil {
+		invalid = append(invalid, invalidOption{key: "check.range", err: err})
 	}
-}
 
-// filesFromFlag converts the --file flag value into the config files list.
-func filesFromFlag(versionFile string) []string {
-	if versionFile == "" {
-		return nil
+	for _, pattern := range slices.Sorted(maps.Keys(conf.Check.Branches)) {
+		policy := conf.Check.Branches[pattern]
+		key := "check.branches." + pattern
+
+		if err := validateRange(key+".range", policy.Range); err != nil {
+			invalid = append(invalid, invalidOption{key: key + ".range", err: err})
+		}
+
+		for _, bump := range policy.Bumps {
+			if !slices.Contains(bumpTypes, bump) {
+				invalid = append(invalid, invalidOption{
+					key: key + ".bumps",
+					err: fmt.Errorf(
+						"%s: %w, must be one of: %s: %s",
+						key+".bumps", version.ErrInvalidIncrementType, strings.Join(bumpTypes, ", "), bump,
+					),
+				})
+			}
+		}
 	}
 
-	return []string{versionFile}
+	return invalid
 }
 
-// FindConfigFile checks the expected paths for a vrsn config file and returns the
-// path to it if found.
-// The paths are checked in the order of precedence:
-//   - current directory (project level config)
-//   - XDG_CONFIG_HOME (with XDG_CONFIG_DIR still supported for backwards
-//     compatibility)
-//   - HOME/.config
-func FindConfigFile() (string, error) {
-	paths := []string{"."}
+// bumpTypes are the bump types branch policies can allow.
+//
+//nolint:gochecknoglobals
+var bumpTypes = []string{vrsn.Patch, vrsn.Minor, vrsn.Major}
 
-	if xdg, ok := os.LookupEnv("XDG_CONFIG_HOME"); ok {
-		paths = append(paths, xdg)
+// validateRange returns an error naming the option with the key when the
+// version range isn't empty and can't be parsed.
+func validateRange(key string, versionRange string) error {
+	if strings.TrimSpace(versionRange) == "" {
+		return nil
 	}
 
-	if xdg, ok := os.LookupEnv("XDG_CONFIG_DIR"); ok {
-		paths = append(paths, xdg)
+	if _, err := version.ParseConstraint(versionRange); err != nil {
+		return fmt.Errorf("%s: %w", key, err)
 	}
 
-	if home, ok := os.LookupEnv("HOME"); ok {
-		paths = append(paths, filepath.Join(home, ".config"))
+	return nil
+}
+
+// flagOverride is a flag that overrides the config options with the keys when
+// it is explicitly set.
+type flagOverride struct {
+	flag  string
+	keys  []string
+	apply func(conf *Config, flagConf Config)
+}
+
+// flagOverrides returns the flags that override config options.
+func flagOverrides() []flagOverride {
+	return []flagOverride{
+		{
+			flag: "android-version-code",
+			keys: []string{"bump.android-version-code", "set.android-version-code"},
+			apply: func(conf *Config, flagConf Config) {
+				conf.Bump.AndroidVersionCode = flagConf.Bump.AndroidVersionCode
+				conf.Set.AndroidVersionCode = flagConf.Set.AndroidVersionCode
+			},
+		},
+		{
+			flag: "apple-build-number",
+			keys: []string{"bump.apple-build-number", "set.apple-build-number"},
+			apply: func(conf *Config, flagConf Config) {
+				conf.Bump.AppleBuildNumber = flagConf.Bump.AppleBuildNumber
+				conf.Set.AppleBuildNumber = flagConf.Set.AppleBuildNumber
+			},
+		},
+		{
+			flag: "pubspec-build-number",
+			keys: []string{"bump.pubspec-build-number", "set.pubspec-build-number"},
+			apply: func(conf *Config, flagConf Config) {
+				conf.Bump.PubspecBuildNumber = flagConf.Bump.PubspecBuildNumber
+				conf.Set.PubspecBuildNumber = flagConf.Set.PubspecBuildNumber
+			},
+		},
+		{
+			flag:  "build-number-strategy",
+			keys:  []string{"build-number.strategy"},
+			apply: func(conf *Config, flagConf Config) { conf.BuildNumber.Strategy = flagConf.BuildNumber.Strategy },
+		},
+		{
+			flag:  "commit",
+			keys:  []string{"bump.commit"},
+			apply: func(conf *Config, flagConf Config) { conf.Bump.Commit = flagConf.Bump.Commit },
+		},
+		{
+			flag:  "commit-msg",
+			keys:  []string{"bump.commit-msg"},
+			apply: func(conf *Config, flagConf Config) { conf.Bump.CommitMsg = flagConf.Bump.CommitMsg },
+		},
+		{
+			flag: "git-tag",
+			keys: []string{"bump.git-tag", "get.git-tag", "next.git-tag"},
+			apply: func(conf *Config, flagConf Config) {
+				conf.Bump.GitTag = flagConf.Bump.GitTag
+				conf.Get.GitTag = flagConf.Get.GitTag
+				conf.Next.GitTag = flagConf.Next.GitTag
+			},
+		},
+		{
+			flag:  "tag-msg",
+			keys:  []string{"bump.tag-msg"},
+			apply: func(conf *Config, flagConf Config) { conf.Bump.TagMsg = flagConf.Bump.TagMsg },
+		},
+		{
+			flag:  "tag-name",
+			keys:  []string{"bump.tag-name"},
+			apply: func(conf *Config, flagConf Config) { conf.Bump.TagName = flagConf.Bump.TagName },
+		},
+		{
+			flag:  "base-branch",
+			keys:  []string{"check.base-branch"},
+			apply: func(conf *Config, flagConf Config) { conf.Check.BaseBranch = flagConf.Check.BaseBranch },
+		},
+		{
+			flag:  "max-version",
+			keys:  []string{"check.max-version"},
+			apply: func(conf *Config, flagConf Config) { conf.Check.MaxVersion = flagConf.Check.MaxVersion },
+		},
+		{
+			flag:  "range",
+			keys:  []string{"check.range"},
+			apply: func(conf *Config, flagConf Config) { conf.Check.Range = flagConf.Check.Range },
+		},
+		{
+			flag:  "color",
+			keys:  []string{"color"},
+			apply: func(conf *Config, flagConf Config) { conf.Color = flagConf.Color },
+		},
+		{
+			flag:  "log-file",
+			keys:  []string{"log-file"},
+			apply: func(conf *Config, flagConf Config) { conf.LogFile = flagConf.LogFile },
+		},
+		{
+			flag:  "log-format",
+			keys:  []string{"log-format"},
+			apply: func(conf *Config, flagConf Config) { conf.LogFormat = flagConf.LogFormat },
+		},
+		{
+			flag:  "log-level",
+			keys:  []string{"log-level"},
+			apply: func(conf *Config, flagConf Config) { conf.LogLevel = flagConf.LogLevel },
+		},
+		{
+			flag:  "verbose",
+			keys:  []string{"verbose"},
+			apply: func(conf *Config, flagConf Config) { conf.Verbose = flagConf.Verbose },
+		},
 	}
+}
 
-	configFileName := "vrsn.toml"
+// applyChangedFlags overrides config file values with any flags that were
+// explicitly set on the command line, so passing a flag always works
+// regardless of which config files are found.
+// Flags not registered on the current command report as unchanged.
+func applyChangedFlags(conf *Config, flagConf Config, flagSet FlagChecker, origins Origins) {
+	if flagSet == nil {
+		return
+	}
 
-	for _, path := range paths {
-		file := filepath.Join(path, configFileName)
-		if _, err := os.Stat(file); os.IsNotExist(err) {
-			// no config file at location, continue looking.
+	for _, override := range flagOverrides() {
+		if !flagSet.Changed(override.flag) {
 			continue
 		}
 
-		return file, nil
+		override.apply(conf, flagConf)
+
+		for _, key := range override.keys {
+			origins[key] = flagOrigin(override.flag)
+		}
 	}
+}
 
-	return "", nil
+// PolicyForBranch returns the branch policy that applies to the branch, along
+// with the pattern it is keyed by. Patterns use path.Match syntax so hotfix/*
+// matches hotfix/login but not hotfix/login/part-2. When several patterns
+// match, the longest, and so most specific, one is used.
+func (c CheckOpts) PolicyForBranch(branch string) (string, BranchPolicy, bool) {
+	return vrsn.PolicyForBranch(c.Branches, branch)
 }
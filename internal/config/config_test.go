@@ -1,12 +1,16 @@
 package config_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/tx3stn/vrsn/internal/buildnumber"
 	"github.com/tx3stn/vrsn/internal/config"
-	"github.com/tx3stn/vrsn/internal/flags"
+	"github.com/tx3stn/vrsn/internal/logger"
+	"github.com/tx3stn/vrsn/internal/version"
 )
 
 func TestGetFiles(t *testing.T) {
@@ -47,20 +51,15 @@ func TestGetFiles(t *testing.T) {
 
 		t.Run(name, func(t *testing.T) {
 			// Ensure no real config file is picked up when no --config flag
-			// is passed, t.Setenv also prevents the tests running in
-			// parallel which keeps the mutation of the global flag var safe.
+			// is passed.
 			t.Setenv("XDG_CONFIG_DIR", "")
 			t.Setenv("XDG_CONFIG_HOME", "")
 			t.Setenv("HOME", "")
 
-			originalVersionFile := flags.VersionFile
-			flags.VersionFile = tc.versionFileFlag
+			flagConf := config.Default()
+			flagConf.Files = config.FilesFromFlag(tc.versionFileFlag)
 
-			t.Cleanup(func() {
-				flags.VersionFile = originalVersionFile
-			})
-
-			conf, err := config.Get(tc.configFile, nil)
+			conf, err := config.Get(tc.configFile, flagConf, nil)
 			require.NoError(t, err)
 			assert.Equal(t, tc.expectedFiles, conf.Files)
 		})
@@ -120,27 +119,14 @@ func TestGetMergesConfigFileAndFlags(t *testing.T) {
 		tc := testCase
 
 		t.Run(name, func(t *testing.T) {
-			// t.Setenv also prevents the tests running in parallel which
-			// keeps the mutation of the global flag vars safe.
 			t.Setenv("XDG_CONFIG_DIR", "")
 			t.Setenv("XDG_CONFIG_HOME", "")
 			t.Setenv("HOME", "")
 
-			originalCommit := flags.Commit
-			originalCommitMsg := flags.CommitMsg
-			originalBaseBranch := flags.BaseBranch
-			flags.Commit = tc.flagCommit
-			// Simulate the defaults cobra applies when registering the flags.
-			flags.CommitMsg = "bump version"
-			flags.BaseBranch = "main"
-
-			t.Cleanup(func() {
-				flags.Commit = originalCommit
-				flags.CommitMsg = originalCommitMsg
-				flags.BaseBranch = originalBaseBranch
-			})
-
-			conf, err := config.Get(tc.configFile, tc.changed)
+			flagConf := config.Default()
+			flagConf.Bump.Commit = tc.flagCommit
+
+			conf, err := config.Get(tc.configFile, flagConf, tc.changed)
 			require.NoError(t, err)
 			assert.Equal(t, tc.expectedBump, conf.Bump)
 			assert.Equal(t, "main", conf.Check.BaseBranch)
@@ -179,42 +165,171 @@ func TestGetSetOptions(t *testing.T) {
 		tc := testCase
 
 		t.Run(name, func(t *testing.T) {
-			// t.Setenv also prevents the tests running in parallel which
-			// keeps the mutation of the global flag var safe.
 			t.Setenv("XDG_CONFIG_DIR", "")
 			t.Setenv("XDG_CONFIG_HOME", "")
 			t.Setenv("HOME", "")
 
-			originalAndroid := flags.AndroidVersionCode
-			flags.AndroidVersionCode = tc.flagAndroid
-
-			t.Cleanup(func() {
-				flags.AndroidVersionCode = originalAndroid
-			})
+			flagConf := config.Default()
+			flagConf.Set.AndroidVersionCode = tc.flagAndroid
 
-			conf, err := config.Get(tc.configFile, tc.changed)
+			conf, err := config.Get(tc.configFile, flagConf, tc.changed)
 			require.NoError(t, err)
 			assert.Equal(t, tc.expected, conf.Set)
 		})
 	}
 }
 
-func TestFindConfigFile(t *testing.T) {
+func TestGetGitTagOptions(t *testing.T) {
+	testCases := map[string]struct {
+		changed      changedFlags
+		flagGitTag   bool
+		expectedBump bool
+		expectedGet  bool
+		expectedNext bool
+	}{
+		"ReadsEachCommandsOptionFromConfig": {
+			changed:      changedFlags{},
+			flagGitTag:   false,
+			expectedBump: true,
+			expectedGet:  true,
+			expectedNext: false,
+		},
+		"ChangedFlagOverridesConfig": {
+			changed:      changedFlags{"git-tag": true},
+			flagGitTag:   false,
+			expectedBump: false,
+			expectedGet:  false,
+			expectedNext: false,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("XDG_CONFIG_DIR", "")
+			t.Setenv("XDG_CONFIG_HOME", "")
+			t.Setenv("HOME", "")
+
+			flagConf := config.Default()
+			flagConf.Get.GitTag = tc.flagGitTag
+
+			conf, err := config.Get("testdata/with-git-tag/vrsn.toml", flagConf, tc.changed)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedBump, conf.Bump.GitTag, "bump")
+			assert.Equal(t, tc.expectedGet, conf.Get.GitTag, "get")
+			assert.Equal(t, tc.expectedNext, conf.Next.GitTag, "next")
+		})
+	}
+}
+
+func TestGetCheckRange(t *testing.T) {
+	testCases := map[string]struct {
+		changed            changedFlags
+		flagRange          string
+		expectedRange      string
+		expectedMaxVersion string
+	}{
+		"ReadsRangeFromConfig": {
+			changed:            changedFlags{},
+			flagRange:          "",
+			expectedRange:      "^1.2",
+			expectedMaxVersion: "1.x",
+		},
+		"ChangedFlagOverridesConfig": {
+			changed:            changedFlags{"range": true},
+			flagRange:          "~1.4",
+			expectedRange:      "~1.4",
+			expectedMaxVersion: "1.x",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("XDG_CONFIG_DIR", "")
+			t.Setenv("XDG_CONFIG_HOME", "")
+			t.Setenv("HOME", "")
+
+			flagConf := config.Default()
+			flagConf.Check.Range = tc.flagRange
+
+			conf, err := config.Get("testdata/with-range/vrsn.toml", flagConf, tc.changed)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRange, conf.Check.Range)
+			assert.Equal(t, tc.expectedMaxVersion, conf.Check.MaxVersion)
+		})
+	}
+}
+
+func TestPolicyForBranch(t *testing.T) {
+	t.Setenv("XDG_CONFIG_DIR", "")
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("HOME", "")
+
+	conf, err := config.Get("testdata/with-branches/vrsn.toml", config.Default(), nil)
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		branch          string
+		expectedPattern string
+		expectedPolicy  config.BranchPolicy
+		expectedFound   bool
+	}{
+		"MatchesExactPattern": {
+			branch:          "release/1.x",
+			expectedPattern: "release/1.x",
+			expectedPolicy:  config.BranchPolicy{Bumps: []string{"patch"}, Range: "1.x"},
+			expectedFound:   true,
+		},
+		"MatchesGlobPattern": {
+			branch:          "release/2.x",
+			expectedPattern: "release/*",
+			expectedPolicy:  config.BranchPolicy{Bumps: []string{"patch", "minor"}, Range: ""},
+			expectedFound:   true,
+		},
+		"GlobDoesNotMatchNestedBranches": {
+			branch:          "hotfix/login/part-2",
+			expectedPattern: "",
+			expectedPolicy:  config.BranchPolicy{Bumps: nil, Range: ""},
+			expectedFound:   false,
+		},
+		"ReturnsNotFoundForUnmatchedBranch": {
+			branch:          "feature/thing",
+			expectedPattern: "",
+			expectedPolicy:  config.BranchPolicy{Bumps: nil, Range: ""},
+			expectedFound:   false,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			pattern, policy, found := conf.Check.PolicyForBranch(tc.branch)
+			assert.Equal(t, tc.expectedPattern, pattern)
+			assert.Equal(t, tc.expectedPolicy, policy)
+			assert.Equal(t, tc.expectedFound, found)
+		})
+	}
+}
+
+func TestGetAnchors(t *testing.T) {
+	t.Setenv("XDG_CONFIG_DIR", "")
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("HOME", "")
+
+	conf, err := config.Get("testdata/with-anchors/vrsn.toml", config.Default(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"Dockerfile":       {"ARG VERSION=", "LABEL version="},
+		"chart/Chart.yaml": {"version:", "appVersion:"},
+	}, conf.Anchors)
+}
+
+func TestFindGlobalConfigFile(t *testing.T) {
 	testCases := map[string]struct {
-		chdir           string
 		xdgEnvValue     string
 		xdgHomeEnvValue string
 		homeEnvValue    string
 		expected        string
 		expectedError   error
 	}{
-		"ReturnsCurrentDirectoryFileWhenExists": {
-			chdir:         "testdata/project",
-			xdgEnvValue:   "testdata/xdg/",
-			homeEnvValue:  "testdata/home/",
-			expected:      "vrsn.toml",
-			expectedError: nil,
-		},
 		"ReturnsXdgFileWhenExists": {
 			xdgEnvValue:   "testdata/xdg/",
 			homeEnvValue:  "testdata/home/",
@@ -249,13 +364,647 @@ func TestFindConfigFile(t *testing.T) {
 			t.Setenv("XDG_CONFIG_HOME", tc.xdgHomeEnvValue)
 			t.Setenv("HOME", tc.homeEnvValue)
 
-			if tc.chdir != "" {
-				t.Chdir(tc.chdir)
+			file, err := config.FindGlobalConfigFile()
+			require.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, tc.expected, file)
+		})
+	}
+}
+
+// writeConfigFiles creates the files, keyed by their path relative to the
+// returned temporary directory, with the content.
+func writeConfigFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	for file, content := range files {
+		path := filepath.Join(dir, file)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+
+	return dir
+}
+
+func TestFindProjectConfigFiles(t *testing.T) {
+	testCases := map[string]struct {
+		files    map[string]string
+		dir      string
+		expected []string
+	}{
+		"ReturnsRootThenPackageConfigInRepository": {
+			files: map[string]string{
+				".git/HEAD":                  "",
+				"vrsn.toml":                  "",
+				"packages/api/vrsn.toml":     "",
+				"packages/api/cmd/README.md": "",
+			},
+			dir:      "packages/api/cmd",
+			expected: []string{"../../../vrsn.toml", "../vrsn.toml"},
+		},
+		"IgnoresConfigAboveRepositoryRoot": {
+			files: map[string]string{
+				"vrsn.toml":      "",
+				"repo/.git/HEAD": "",
+				"repo/README.md": "",
+			},
+			dir:      "repo",
+			expected: []string{},
+		},
+		"ChecksOnlyDirectoryOutsideRepository": {
+			files: map[string]string{
+				"vrsn.toml":         "",
+				"project/vrsn.toml": "",
+			},
+			dir:      "project",
+			expected: []string{"vrsn.toml"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			root := writeConfigFiles(t, tc.files)
+
+			files, err := config.FindProjectConfigFiles(filepath.Join(root, tc.dir))
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, files)
+		})
+	}
+}
+
+func TestLoadLayersConfigFiles(t *testing.T) {
+	root := writeConfigFiles(t, map[string]string{
+		"xdg/vrsn.toml": `color = 'never'
+[bump]
+commit = true
+commit-msg = 'global'
+`,
+		"repo/.git/HEAD": "",
+		"repo/vrsn.toml": `[bump]
+commit-msg = 'root'
+[anchors]
+Dockerfile = ['ARG VERSION=']
+`,
+		"repo/api/vrsn.toml": `[bump]
+tag-msg = 'api'
+[anchors]
+'chart/Chart.yaml' = ['version:']
+`,
+	})
+
+	t.Setenv("XDG_CONFIG_DIR", "")
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(root, "xdg"))
+	t.Setenv("HOME", "")
+	t.Chdir(filepath.Join(root, "repo", "api"))
+
+	flagConf := config.Default()
+	flagConf.LogLevel = "debug"
+
+	conf, origins, err := config.Load("", flagConf, changedFlags{"log-level": true})
+	require.NoError(t, err)
+
+	assert.Equal(t, config.BumpOpts{
+		Commit:    true,
+		CommitMsg: "root",
+		TagMsg:    "api",
+	}, conf.Bump)
+	assert.Equal(t, map[string][]string{
+		"../Dockerfile":    {"ARG VERSION="},
+		"chart/Chart.yaml": {"version:"},
+	}, conf.Anchors)
+	assert.Equal(t, "never", conf.Color)
+	assert.Equal(t, "debug", conf.LogLevel)
+
+	globalFile := filepath.Join(root, "xdg", "vrsn.toml")
+
+	testCases := map[string]string{
+		"bump.commit":              globalFile,
+		"bump.commit-msg":          "../vrsn.toml",
+		"bump.tag-msg":             "vrsn.toml",
+		"anchors.../Dockerfile":    "../vrsn.toml",
+		"anchors.chart/Chart.yaml": "vrsn.toml",
+		"color":                    globalFile,
+		"log-level":                "flag --log-level",
+		"check.base-branch":        config.OriginDefault,
+		"set.android-version-code": config.OriginDefault,
+	}
+
+	for key, expected := range testCases {
+		t.Run(key, func(t *testing.T) {
+			assert.Equal(t, expected, origins.Of(key))
+		})
+	}
+}
+
+func TestLoadResolvesPathsAgainstConfigFileDirectory(t *testing.T) {
+	testCases := map[string]struct {
+		packageConfig   string
+		expectedFiles   []string
+		expectedAnchors map[string][]string
+	}{
+		"ResolvesRootPathsFromPackage": {
+			packageConfig: "",
+			expectedFiles: []string{"../../VERSION", "../../Dockerfile"},
+			expectedAnchors: map[string][]string{
+				"../../Dockerfile": {"ARG VERSION="},
+			},
+		},
+		"PackagePathsOverrideRootPaths": {
+			packageConfig: `files = ['package.json', 'Dockerfile']
+[anchors]
+Dockerfile = ['LABEL version=']
+`,
+			expectedFiles: []string{"package.json", "Dockerfile"},
+			expectedAnchors: map[string][]string{
+				"../../Dockerfile": {"ARG VERSION="},
+				"Dockerfile":       {"LABEL version="},
+			},
+		},
+		"KeepsAbsolutePaths": {
+			packageConfig: "files = ['/srv/VERSION']\n",
+			expectedFiles: []string{"/srv/VERSION"},
+			expectedAnchors: map[string][]string{
+				"../../Dockerfile": {"ARG VERSION="},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			configFiles := map[string]string{
+				"repo/.git/HEAD": "",
+				"repo/vrsn.toml": `files = ['VERSION', 'Dockerfile']
+[anchors]
+Dockerfile = ['ARG VERSION=']
+`,
+				"repo/packages/api/README.md": "",
+			}
+
+			if tc.packageConfig != "" {
+				configFiles["repo/packages/api/vrsn.toml"] = tc.packageConfig
+			}
+
+			root := writeConfigFiles(t, configFiles)
+
+			t.Setenv("XDG_CONFIG_DIR", "")
+			t.Setenv("XDG_CONFIG_HOME", filepath.Join(root, "xdg"))
+			t.Setenv("HOME", "")
+			t.Chdir(filepath.Join(root, "repo", "packages", "api"))
+
+			conf, err := config.Get("", config.Default(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedFiles, conf.Files)
+			assert.Equal(t, tc.expectedAnchors, conf.Anchors)
+		})
+	}
+}
+
+func TestLoadConfigFlagReplacesConfigFiles(t *testing.T) {
+	t.Setenv("XDG_CONFIG_DIR", "")
+	t.Setenv("XDG_CONFIG_HOME", "testdata/xdg")
+	t.Setenv("HOME", "")
+
+	conf, origins, err := config.Load("testdata/with-range/vrsn.toml", config.Default(), nil)
+	require.NoError(t, err)
+	assert.False(t, conf.Bump.Commit)
+	assert.Equal(t, "testdata/with-range/vrsn.toml", origins.Of("check.range"))
+	assert.Equal(t, config.OriginDefault, origins.Of("bump.commit"))
+}
+
+func TestSettings(t *testing.T) {
+	conf := config.Default()
+	conf.Files = []string{"VERSION", "package.json"}
+	conf.Anchors = map[string][]string{"Dockerfile": {"ARG VERSION="}}
+
+	settings, err := config.Settings(conf)
+	require.NoError(t, err)
+
+	values := map[string]string{}
+	for _, setting := range settings {
+		values[setting.Key] = setting.Value
+	}
+
+	assert.Equal(t, `["VERSION", "package.json"]`, values["files"])
+	assert.Equal(t, `["ARG VERSION="]`, values["anchors.Dockerfile"])
+	assert.Equal(t, `"bump version"`, values["bump.commit-msg"])
+	assert.Equal(t, "false", values["bump.commit"])
+	assert.IsIncreasing(t, keys(settings))
+}
+
+func keys(settings []config.Setting) []string {
+	keys := make([]string, 0, len(settings))
+	for _, setting := range settings {
+		keys = append(keys, setting.Key)
+	}
+
+	return keys
+}
+
+func TestGetValidatesColor(t *testing.T) {
+	testCases := map[string]struct {
+		changed       changedFlags
+		flagColor     string
+		expectedColor string
+		expectedError error
+	}{
+		"RejectsInvalidColorFromConfig": {
+			changed:       changedFlags{},
+			flagColor:     "auto",
+			expectedColor: "",
+			expectedError: logger.ErrInvalidColorMode,
+		},
+		"ChangedFlagOverridesConfig": {
+			changed:       changedFlags{"color": true},
+			flagColor:     "never",
+			expectedColor: "never",
+			expectedError: nil,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("XDG_CONFIG_DIR", "")
+			t.Setenv("XDG_CONFIG_HOME", "")
+			t.Setenv("HOME", "")
+
+			flagConf := config.Default()
+			flagConf.Color = tc.flagColor
+
+			conf, err := config.Get("testdata/with-color/vrsn.toml", flagConf, tc.changed)
+			require.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, tc.expectedColor, conf.Color)
+		})
+	}
+}
+
+func TestGetValidatesLogLevel(t *testing.T) {
+	t.Setenv("XDG_CONFIG_DIR", "")
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("HOME", "")
+
+	flagConf := config.Default()
+	flagConf.LogLevel = "loud"
+
+	_, err := config.Get("", flagConf, nil)
+	require.ErrorIs(t, err, logger.ErrInvalidLogLevel)
+}
+
+func TestGetValidatesCheckOptions(t *testing.T) {
+	testCases := map[string]struct {
+		check         config.CheckOpts
+		expectedError error
+	}{
+		"RejectsInvalidRange": {
+			check:         config.CheckOpts{BaseBranch: "main", Range: "^^1 garbage"},
+			expectedError: version.ErrInvalidConstraint,
+		},
+		"RejectsInvalidBranchPolicyRange": {
+			check: config.CheckOpts{
+				BaseBranch: "main",
+				Branches:   map[string]config.BranchPolicy{"release/*": {Range: ">=1 <"}},
+			},
+			expectedError: version.ErrInvalidConstraint,
+		},
+		"RejectsUnknownBranchPolicyBump": {
+			check: config.CheckOpts{
+				BaseBranch: "main",
+				Branches:   map[string]config.BranchPolicy{"release/*": {Bumps: []string{"ptach"}}},
+			},
+			expectedError: version.ErrInvalidIncrementType,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("XDG_CONFIG_DIR", "")
+			t.Setenv("XDG_CONFIG_HOME", "")
+			t.Setenv("HOME", "")
+
+			flagConf := config.Default()
+			flagConf.Check = tc.check
+
+			_, err := config.Get("", flagConf, nil)
+			require.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestGetBuildNumber(t *testing.T) {
+	testCases := map[string]struct {
+		env           map[string]string
+		changed       changedFlags
+		flagStrategy  string
+		expected      config.BuildNumberOpts
+		expectedError error
+	}{
+		"ConfigFileOverridesDefaults": {
+			env:          map[string]string{},
+			changed:      changedFlags{},
+			flagStrategy: buildnumber.Formula,
+			expected: config.BuildNumberOpts{
+				MinorDigits: 3,
+				PatchDigits: buildnumber.DefaultDigits,
+				Strategy:    buildnumber.Increment,
+			},
+			expectedError: nil,
+		},
+		"EnvOverridesConfigFile": {
+			env:          map[string]string{"VRSN_BUILD_NUMBER_PATCH_DIGITS": "4"},
+			changed:      changedFlags{},
+			flagStrategy: buildnumber.Formula,
+			expected: config.BuildNumberOpts{
+				MinorDigits: 3,
+				PatchDigits: 4,
+				Strategy:    buildnumber.Increment,
+			},
+			expectedError: nil,
+		},
+		"ChangedFlagOverridesConfig": {
+			env:          map[string]string{},
+			changed:      changedFlags{"build-number-strategy": true},
+			flagStrategy: buildnumber.CommitCount,
+			expected: config.BuildNumberOpts{
+				MinorDigits: 3,
+				PatchDigits: buildnumber.DefaultDigits,
+				Strategy:    buildnumber.CommitCount,
+			},
+			expectedError: nil,
+		},
+		"RejectsInvalidStrategy": {
+			env:           map[string]string{},
+			changed:       changedFlags{"build-number-strategy": true},
+			flagStrategy:  "random",
+			expected:      config.BuildNumberOpts{},
+			expectedError: buildnumber.ErrInvalidStrategy,
+		},
+		"RejectsInvalidDigits": {
+			env:           map[string]string{"VRSN_BUILD_NUMBER_MINOR_DIGITS": "0"},
+			changed:       changedFlags{},
+			flagStrategy:  buildnumber.Formula,
+			expected:      config.BuildNumberOpts{},
+			expectedError: buildnumber.ErrInvalidDigits,
+		},
+		"RejectsNonNumericDigits": {
+			env:           map[string]string{"VRSN_BUILD_NUMBER_MINOR_DIGITS": "three"},
+			changed:       changedFlags{},
+			flagStrategy:  buildnumber.Formula,
+			expected:      config.BuildNumberOpts{},
+			expectedError: config.ErrInvalidEnvVar,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("XDG_CONFIG_DIR", "")
+			t.Setenv("XDG_CONFIG_HOME", "")
+			t.Setenv("HOME", "")
+
+			for key, value := range tc.env {
+				t.Setenv(key, value)
 			}
 
-			file, err := config.FindConfigFile()
+			flagConf := config.Default()
+			flagConf.BuildNumber.Strategy = tc.flagStrategy
+
+			conf, err := config.Get("testdata/with-build-number/vrsn.toml", flagConf, tc.changed)
 			require.ErrorIs(t, err, tc.expectedError)
-			assert.Equal(t, tc.expected, file)
+			assert.Equal(t, tc.expected, conf.BuildNumber)
+		})
+	}
+}
+
+func TestLoadEnvVars(t *testing.T) {
+	testCases := map[string]struct {
+		env             map[string]string
+		changed         changedFlags
+		flagBaseBranch  string
+		expectedCommit  bool
+		expectedBranch  string
+		expectedFiles   []string
+		expectedOrigins map[string]string
+		expectedError   error
+	}{
+		"EnvOverridesConfigFile": {
+			env: map[string]string{
+				"VRSN_BUMP_COMMIT":       "true",
+				"VRSN_CHECK_BASE_BRANCH": "develop",
+				"VRSN_FILES":             "VERSION, package.json",
+			},
+			changed:        changedFlags{},
+			flagBaseBranch: "main",
+			expectedCommit: true,
+			expectedBranch: "develop",
+			expectedFiles:  []string{"VERSION", "package.json"},
+			expectedOrigins: map[string]string{
+				"bump.commit":       "env VRSN_BUMP_COMMIT",
+				"check.base-branch": "env VRSN_CHECK_BASE_BRANCH",
+				"files":             "env VRSN_FILES",
+			},
+			expectedError: nil,
+		},
+		"ChangedFlagOverridesEnv": {
+			env:            map[string]string{"VRSN_CHECK_BASE_BRANCH": "develop"},
+			changed:        changedFlags{"base-branch": true},
+			flagBaseBranch: "trunk",
+			expectedCommit: false,
+			expectedBranch: "trunk",
+			expectedFiles:  []string{"VERSION", "package.json"},
+			expectedOrigins: map[string]string{
+				"check.base-branch": "flag --base-branch",
+				"files":             "testdata/with-files/vrsn.toml",
+			},
+			expectedError: nil,
+		},
+		"IgnoresEmptyEnv": {
+			env:             map[string]string{"VRSN_BUMP_COMMIT": ""},
+			changed:         changedFlags{},
+			flagBaseBranch:  "main",
+			expectedCommit:  false,
+			expectedBranch:  "main",
+			expectedFiles:   []string{"VERSION", "package.json"},
+			expectedOrigins: map[string]string{"bump.commit": "testdata/with-files/vrsn.toml"},
+			expectedError:   nil,
+		},
+		"RejectsInvalidBool": {
+			env:             map[string]string{"VRSN_BUMP_COMMIT": "yes please"},
+			changed:         changedFlags{},
+			flagBaseBranch:  "main",
+			expectedCommit:  false,
+			expectedBranch:  "",
+			expectedFiles:   nil,
+			expectedOrigins: map[string]string{},
+			expectedError:   config.ErrInvalidEnvVar,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("XDG_CONFIG_DIR", "")
+			t.Setenv("XDG_CONFIG_HOME", "")
+			t.Setenv("HOME", "")
+
+			for key, value := range tc.env {
+				t.Setenv(key, value)
+			}
+
+			flagConf := config.Default()
+			flagConf.Check.BaseBranch = tc.flagBaseBranch
+
+			conf, origins, err := config.Load("testdata/with-files/vrsn.toml", flagConf, tc.changed)
+			require.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, tc.expectedCommit, conf.Bump.Commit)
+			assert.Equal(t, tc.expectedBranch, conf.Check.BaseBranch)
+			assert.Equal(t, tc.expectedFiles, conf.Files)
+
+			for key, expected := range tc.expectedOrigins {
+				assert.Equal(t, expected, origins.Of(key))
+			}
+		})
+	}
+}
+
+func TestLoadEnvVarsForTablesAndHooks(t *testing.T) {
+	t.Setenv("XDG_CONFIG_DIR", "")
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("HOME", "")
+	t.Setenv("VRSN_ANCHORS", `{ Dockerfile = ['LABEL version='] }`)
+	t.Setenv("VRSN_CHECK_BRANCHES", `{ main = { bumps = ['patch'] }, 'release/*' = { range = '^1' } }`)
+	t.Setenv("VRSN_HOOKS_PRE_BUMP", `['make test', 'echo "a, b"']`)
+	t.Setenv("VRSN_HOOKS_COMMIT_FILES", "CHANGELOG.md, docs/")
+
+	conf, origins, err := config.Load("testdata/with-anchors/vrsn.toml", config.Default(), nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string][]string{
+		"Dockerfile":       {"LABEL version="},
+		"chart/Chart.yaml": {"version:", "appVersion:"},
+	}, conf.Anchors)
+	assert.Equal(t, map[string]config.BranchPolicy{
+		"main":      {Bumps: []string{"patch"}},
+		"release/*": {Range: "^1"},
+	}, conf.Check.Branches)
+	assert.Equal(t, []string{"make test", `echo "a, b"`}, conf.Hooks.PreBump)
+	assert.Equal(t, []string{"CHANGELOG.md", "docs/"}, conf.Hooks.CommitFiles)
+
+	testCases := map[string]string{
+		"anchors.Dockerfile":             "env VRSN_ANCHORS",
+		"anchors.chart/Chart.yaml":       "testdata/with-anchors/vrsn.toml",
+		"check.branches.main.bumps":      "env VRSN_CHECK_BRANCHES",
+		"check.branches.release/*.range": "env VRSN_CHECK_BRANCHES",
+		"hooks.pre-bump":                 "env VRSN_HOOKS_PRE_BUMP",
+		"hooks.commit-files":             "env VRSN_HOOKS_COMMIT_FILES",
+	}
+
+	for key, expected := range testCases {
+		t.Run(key, func(t *testing.T) {
+			assert.Equal(t, expected, origins.Of(key))
 		})
 	}
 }
+
+func TestLoadRejectsInvalidTOMLEnvVar(t *testing.T) {
+	t.Setenv("XDG_CONFIG_DIR", "")
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("HOME", "")
+	t.Setenv("VRSN_HOOKS_POST_WRITE", "make changelog")
+
+	_, _, err := config.Load("testdata/with-files/vrsn.toml", config.Default(), nil)
+	require.ErrorIs(t, err, config.ErrInvalidEnvVar)
+	assert.ErrorContains(t, err, "VRSN_HOOKS_POST_WRITE must be a TOML value")
+}
+
+func TestEnvVarName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "VRSN_CHECK_BASE_BRANCH", config.EnvVarName("check.base-branch"))
+	assert.Equal(t, "VRSN_FILES", config.EnvVarName("files"))
+}
+
+func TestValidateFile(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		file     string
+		expected []string
+	}{
+		"ReturnsNoProblemsForValidFile": {
+			file:     "testdata/with-branches/vrsn.toml",
+			expected: []string{},
+		},
+		"ReportsUnknownKeysWithPosition": {
+			file: "testdata/unknown-keys/vrsn.toml",
+			expected: []string{
+				"testdata/unknown-keys/vrsn.toml:4:1: unknown key bump.comit",
+				"testdata/unknown-keys/vrsn.toml:7:2: unknown key chek",
+			},
+		},
+		"ReportsWrongType": {
+			file: "testdata/wrong-type/vrsn.toml",
+			expected: []string{
+				"testdata/wrong-type/vrsn.toml:2:10: invalid bump.commit: expected boolean, found string",
+			},
+		},
+		"ReportsInvalidValue": {
+			file: "testdata/with-color/vrsn.toml",
+			expected: []string{
+				"testdata/with-color/vrsn.toml: invalid color mode, must be one of: auto, always, never: sometimes",
+			},
+		},
+		"ReportsEveryProblem": {
+			file: "testdata/many-problems/vrsn.toml",
+			expected: []string{
+				"testdata/many-problems/vrsn.toml:4:10: invalid bump.commit: expected boolean, found string",
+				"testdata/many-problems/vrsn.toml: invalid check.base-branch: expected string, found integer",
+				"testdata/many-problems/vrsn.toml:5:1: unknown key bump.comit",
+				"testdata/many-problems/vrsn.toml: invalid color mode, must be one of: auto, always, never: blue",
+				"testdata/many-problems/vrsn.toml: check.range: invalid version constraint: ^^1 garbage: " +
+					"error converting version part to int: ^1",
+				"testdata/many-problems/vrsn.toml: check.branches.release/*.bumps: invalid increment type, " +
+					"must be one of: patch, minor, major: ptach",
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			problems, err := config.ValidateFile(tc.file)
+			require.NoError(t, err)
+
+			actual := []string{}
+			for _, problem := range problems {
+				actual = append(actual, problem.String())
+			}
+
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestScaffold(t *testing.T) {
+	t.Parallel()
+
+	content, err := config.Scaffold(config.InitOptions{
+		BaseBranch: "develop",
+		Commit:     true,
+		Files:      []string{"VERSION", "package.json"},
+		GitTag:     false,
+	})
+	require.NoError(t, err)
+
+	file := filepath.Join(t.TempDir(), "vrsn.toml")
+	require.NoError(t, config.WriteFile(file, content, false))
+	require.ErrorIs(t, config.WriteFile(file, content, false), config.ErrConfigFileExists)
+
+	problems, err := config.ValidateFile(file)
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+
+	conf, err := config.Get(file, config.Default(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"VERSION", "package.json"}, conf.Files)
+	assert.True(t, conf.Bump.Commit)
+	assert.Equal(t, "develop", conf.Check.BaseBranch)
+}
@@ -0,0 +1,248 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// envPrefix is the prefix of the environment variables that set config
+// options.
+const envPrefix = "VRSN_"
+
+// envVar sets the config option with the key from the environment variable
+// named after it, see EnvVarName.
+type envVar struct {
+	key   string
+	apply func(conf *Config, value string) error
+	// table is set for the tables keyed by name, which record the origin of
+	// each entry the variable sets rather than of the whole table.
+	table bool
+}
+
+// EnvVarName returns the name of the environment variable that sets the config
+// option with the dotted key, e.g. VRSN_CHECK_BASE_BRANCH for
+// check.base-branch.
+func EnvVarName(key string) string {
+	return envPrefix + strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(key))
+}
+
+// EnvVars returns the environment variables that set config options, in the
+// order they are applied, as NAME=value.
+func EnvVars() []string {
+	applied := []string{}
+
+	for _, env := range envVars() {
+		name := EnvVarName(env.key)
+
+		if value := os.Getenv(name); value != "" {
+			applied = append(applied, name+"="+value)
+		}
+	}
+
+	return applied
+}
+
+// envVars returns the environment variables for every config option.
+func envVars() []envVar {
+	return []envVar{
+		tableEnv("anchors", func(c *Config) *map[string][]string { return &c.Anchors }),
+		boolEnv("bump.android-version-code", func(c *Config) *bool { return &c.Bump.AndroidVersionCode }),
+		boolEnv("bump.apple-build-number", func(c *Config) *bool { return &c.Bump.AppleBuildNumber }),
+		boolEnv("bump.commit", func(c *Config) *bool { return &c.Bump.Commit }),
+		stringEnv("bump.commit-msg", func(c *Config) *string { return &c.Bump.CommitMsg }),
+		boolEnv("bump.git-tag", func(c *Config) *bool { return &c.Bump.GitTag }),
+		boolEnv("bump.pubspec-build-number", func(c *Config) *bool { return &c.Bump.PubspecBuildNumber }),
+		stringEnv("bump.tag-msg", func(c *Config) *string { return &c.Bump.TagMsg }),
+		stringEnv("bump.tag-name", func(c *Config) *string { return &c.Bump.TagName }),
+		intEnv("build-number.minor-digits", func(c *Config) *int { return &c.BuildNumber.MinorDigits }),
+		intEnv("build-number.patch-digits", func(c *Config) *int { return &c.BuildNumber.PatchDigits }),
+		stringEnv("build-number.strategy", func(c *Config) *string { return &c.BuildNumber.Strategy }),
+		stringEnv("check.base-branch", func(c *Config) *string { return &c.Check.BaseBranch }),
+		tableEnv("check.branches", func(c *Config) *map[string]BranchPolicy { return &c.Check.Branches }),
+		stringEnv("check.max-version", func(c *Config) *string { return &c.Check.MaxVersion }),
+		stringEnv("check.range", func(c *Config) *string { return &c.Check.Range }),
+		stringEnv("color", func(c *Config) *string { return &c.Color }),
+		listEnv("files", func(c *Config) *[]string { return &c.Files }),
+		boolEnv("get.git-tag", func(c *Config) *bool { return &c.Get.GitTag }),
+		listEnv("hooks.commit-files", func(c *Config) *[]string { return &c.Hooks.CommitFiles }),
+		tomlEnv("hooks.post-tag", func(c *Config) *[]string { return &c.Hooks.PostTag }),
+		tomlEnv("hooks.post-write", func(c *Config) *[]string { return &c.Hooks.PostWrite }),
+		tomlEnv("hooks.pre-bump", func(c *Config) *[]string { return &c.Hooks.PreBump }),
+		tomlEnv("hooks.pre-commit", func(c *Config) *[]string { return &c.Hooks.PreCommit }),
+		stringEnv("log-file", func(c *Config) *string { return &c.LogFile }),
+		stringEnv("log-format", func(c *Config) *string { return &c.LogFormat }),
+		stringEnv("log-level", func(c *Config) *string { return &c.LogLevel }),
+		boolEnv("next.git-tag", func(c *Config) *bool { return &c.Next.GitTag }),
+		boolEnv("set.android-version-code", func(c *Config) *bool { return &c.Set.AndroidVersionCode }),
+		boolEnv("set.apple-build-number", func(c *Config) *bool { return &c.Set.AppleBuildNumber }),
+		boolEnv("set.pubspec-build-number", func(c *Config) *bool { return &c.Set.PubspecBuildNumber }),
+		boolEnv("verbose", func(c *Config) *bool { return &c.Verbose }),
+	}
+}
+
+// boolEnv returns the environment variable for the bool option, parsed with
+// strconv.ParseBool so true, false, 1 and 0 are all accepted.
+func boolEnv(key string, field func(conf *Config) *bool) envVar {
+	return envVar{
+		key: key,
+		apply: func(conf *Config, value string) error {
+			parsed, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("%w: %s must be true or false", ErrInvalidEnvVar, EnvVarName(key))
+			}
+
+			*field(conf) = parsed
+
+			return nil
+		},
+	}
+}
+
+// intEnv returns the environment variable for the integer option.
+func intEnv(key string, field func(conf *Config) *int) envVar {
+	return envVar{
+		key: key,
+		apply: func(conf *Config, value string) error {
+			parsed, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("%w: %s must be a whole number", ErrInvalidEnvVar, EnvVarName(key))
+			}
+
+			*field(conf) = parsed
+
+			return nil
+		},
+	}
+}
+
+// stringEnv returns the environment variable for the string option.
+func stringEnv(key string, field func(conf *Config) *string) envVar {
+	return envVar{
+		key: key,
+		apply: func(conf *Config, value string) error {
+			*field(conf) = value
+
+			return nil
+		},
+	}
+}
+
+// listEnv returns the environment variable for the list option, a comma
+// separated list, see splitList.
+func listEnv(key string, field func(conf *Config) *[]string) envVar {
+	return envVar{
+		key: key,
+		apply: func(conf *Config, value string) error {
+			*field(conf) = splitList(value)
+
+			return nil
+		},
+	}
+}
+
+// tomlEnv returns the environment variable for the option, parsed as the TOML
+// value the option has in a config file, e.g. ['make test'] for a list of
+// hook commands, as its items can contain commas.
+func tomlEnv[T any](key string, field func(conf *Config) *T) envVar {
+	return envVar{
+		key: key,
+		apply: func(conf *Config, value string) error {
+			return parseTOMLValue(EnvVarName(key), value, field(conf))
+		},
+	}
+}
+
+// tableEnv returns the environment variable for the table keyed by name,
+// parsed as a TOML inline table, e.g. { Dockerfile = ['ARG VERSION='] } for
+// anchors. Like the tables in config files, its entries are merged into the
+// table rather than replacing it.
+func tableEnv[T any](key string, field func(conf *Config) *map[string]T) envVar {
+	return envVar{
+		key: key,
+		apply: func(conf *Config, value string) error {
+			entries := map[string]T{}
+			if err := parseTOMLValue(EnvVarName(key), value, &entries); err != nil {
+				return err
+			}
+
+			table := field(conf)
+			if *table == nil {
+				*table = map[string]T{}
+			}
+
+			maps.Copy(*table, entries)
+
+			return nil
+		},
+		table: true,
+	}
+}
+
+// parseTOMLValue parses the value of the environment variable as a TOML value
+// into target.
+func parseTOMLValue[T any](name string, value string, target *T) error {
+	wrapper := struct {
+		Value *T `toml:"value"`
+	}{Value: target}
+
+	if err := toml.Unmarshal([]byte("value = "+value), &wrapper); err != nil {
+		return fmt.Errorf("%w: %s must be a TOML value: %w", ErrInvalidEnvVar, name, err)
+	}
+
+	return nil
+}
+
+// applyEnv sets the config options from their environment variables,
+// recording the variable as the origin of the options it sets.
+// Empty variables are ignored, as CI systems often set variables to an empty
+// string rather than leaving them unset.
+func applyEnv(conf *Config, origins Origins) error {
+	for _, env := range envVars() {
+		name := EnvVarName(env.key)
+
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+
+		if err := env.apply(conf, value); err != nil {
+			return err
+		}
+
+		if !env.table {
+			origins[env.key] = envOrigin(name)
+
+			continue
+		}
+
+		entries := map[string]any{}
+		if err := parseTOMLValue(name, value, &entries); err != nil {
+			return err
+		}
+
+		for entry := range flatten(env.key, entries) {
+			origins[entry] = envOrigin(name)
+		}
+	}
+
+	return nil
+}
+
+// splitList splits the comma separated list, ignoring surrounding whitespace
+// and empty items.
+func splitList(value string) []string {
+	items := []string{}
+
+	for item := range strings.SplitSeq(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
@@ -0,0 +1,59 @@
+package config
+
+import "strconv"
+
+// Error is the error type.
+type Error uint
+
+const (
+	// ErrReadingConfigFile is the error when the config file exists but can't
+	// be read.
+	ErrReadingConfigFile Error = iota + 1
+	// ErrParsingConfigFile is the error when the config file isn't valid TOML
+	// or doesn't match the config schema.
+	ErrParsingConfigFile
+	// ErrInvalidEnvVar is the error when a VRSN_* environment variable has a
+	// value that isn't valid for the config option it sets.
+	ErrInvalidEnvVar
+	// ErrInvalidConfigFile is the error when config validate finds problems in
+	// a config file.
+	ErrInvalidConfigFile
+	// ErrConfigFileExists is the error when config init would replace an
+	// existing config file.
+	ErrConfigFileExists
+	// ErrWritingConfigFile is the error when config init can't write the new
+	// config file.
+	ErrWritingConfigFile
+)
+
+// Error returns the error string for the error enum.
+func (e Error) Error() string {
+	switch e {
+	case ErrReadingConfigFile:
+		return "error reading config file"
+
+	case ErrParsingConfigFile:
+		return "error unmarshalling config from file"
+
+	case ErrInvalidEnvVar:
+		return "invalid config environment variable"
+
+	case ErrInvalidConfigFile:
+		return "invalid config file"
+
+	case ErrConfigFileExists:
+		return "config file already exists, use --force to replace it"
+
+	case ErrWritingConfigFile:
+		return "error writing config file"
+
+	default:
+		return "unknown error"
+	}
+}
+
+// Code returns the stable code for the error enum used in machine readable
+// output.
+func (e Error) Code() string {
+	return "config." + strconv.FormatUint(uint64(e), 10)
+}
@@ -0,0 +1,189 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// configFileName is the name of vrsn config files.
+const configFileName = "vrsn.toml"
+
+// FindConfigFiles returns the config files that apply to the current
+// directory, in the order they are layered, each overriding the options it
+// sets in the ones before it: the global config file, then the project config
+// files, see FindGlobalConfigFile and FindProjectConfigFiles.
+func FindConfigFiles() ([]string, error) {
+	global, project, err := findConfigFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	if global == "" {
+		return project, nil
+	}
+
+	return append([]string{global}, project...), nil
+}
+
+// findConfigFiles returns the global config file, or an empty string when
+// there isn't one, and the project config files relative to the current
+// directory.
+func findConfigFiles() (string, []string, error) {
+	global, err := FindGlobalConfigFile()
+	if err != nil {
+		return "", nil, err
+	}
+
+	curDir, err := os.Getwd()
+	if err != nil {
+		return "", nil, fmt.Errorf("error getting current working directory: %w", err)
+	}
+
+	project, err := FindProjectConfigFiles(curDir)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// A global config file that is also a project config file, e.g. when
+	// running in the config directory, is only layered once, as project config.
+	for _, file := range project {
+		if sameFile(global, file) {
+			return "", project, nil
+		}
+	}
+
+	return global, project, nil
+}
+
+// FindGlobalConfigFile checks the expected paths for the user's vrsn config
+// file and returns the path to it if found.
+// The paths are checked in the order of precedence:
+//   - XDG_CONFIG_HOME (with XDG_CONFIG_DIR still supported for backwards
+//     compatibility)
+//   - HOME/.config
+func FindGlobalConfigFile() (string, error) {
+	paths := []string{}
+
+	if xdg, ok := os.LookupEnv("XDG_CONFIG_HOME"); ok {
+		paths = append(paths, xdg)
+	}
+
+	if xdg, ok := os.LookupEnv("XDG_CONFIG_DIR"); ok {
+		paths = append(paths, xdg)
+	}
+
+	if home, ok := os.LookupEnv("HOME"); ok {
+		paths = append(paths, filepath.Join(home, ".config"))
+	}
+
+	for _, path := range paths {
+		file := filepath.Join(path, configFileName)
+
+		exists, err := fileExists(file)
+		if err != nil {
+			return "", err
+		}
+
+		if exists {
+			return file, nil
+		}
+	}
+
+	return "", nil
+}
+
+// FindProjectConfigFiles returns the config files of the project the directory
+// is in, from the repository root down to the directory, so the config of a
+// package in a monorepo overrides the repository wide config.
+// The repository root is the closest parent directory containing .git,
+// outside of a git repository only the directory itself is checked.
+// The files are returned relative to the directory.
+func FindProjectConfigFiles(dir string) ([]string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("error getting absolute path of %s: %w", dir, err)
+	}
+
+	dirs := []string{dir}
+
+	if root, ok := repoRoot(dir); ok {
+		for parent := dir; parent != root; {
+			parent = filepath.Dir(parent)
+			dirs = append(dirs, parent)
+		}
+	}
+
+	files := []string{}
+
+	for _, configDir := range slices.Backward(dirs) {
+		file := filepath.Join(configDir, configFileName)
+
+		exists, err := fileExists(file)
+		if err != nil {
+			return nil, err
+		}
+
+		if !exists {
+			continue
+		}
+
+		if rel, err := filepath.Rel(dir, file); err == nil {
+			file = rel
+		}
+
+		files = append(files, file)
+	}
+
+	return files, nil
+}
+
+// repoRoot returns the closest directory, starting from dir, that contains
+// .git, which is a directory in a repository and a file in a worktree or
+// submodule.
+func repoRoot(dir string) (string, bool) {
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return dir, true
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+
+		dir = parent
+	}
+}
+
+// fileExists reports whether there is a file at the path, returning an error
+// if it can't be checked, e.g. due to permissions.
+func fileExists(path string) (bool, error) {
+	_, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("%w: %w", ErrReadingConfigFile, err)
+	}
+
+	return true, nil
+}
+
+// sameFile reports whether both paths are the same file.
+func sameFile(first string, second string) bool {
+	firstInfo, err := os.Stat(first)
+	if err != nil {
+		return false
+	}
+
+	secondInfo, err := os.Stat(second)
+	if err != nil {
+		return false
+	}
+
+	return os.SameFile(firstInfo, secondInfo)
+}
@@ -0,0 +1,83 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// schemaURL is the JSON schema of the config file, referenced from new config
+// files so editors can validate and complete them.
+const schemaURL = "https://raw.githubusercontent.com/tx3stn/vrsn/main/.schema/vrsn.json"
+
+// InitOptions are the options written to a new config file by config init.
+type InitOptions struct {
+	BaseBranch string
+	Commit     bool
+	Files      []string
+	GitTag     bool
+}
+
+// scaffold is the layout of a new config file, a subset of Config with only
+// the options most projects set.
+type scaffold struct {
+	Files []string `toml:"files,omitempty"`
+	Bump  struct {
+		Commit bool `toml:"commit"`
+		GitTag bool `toml:"git-tag"`
+	} `toml:"bump"`
+	Check struct {
+		BaseBranch string `toml:"base-branch"`
+	} `toml:"check"`
+}
+
+// Scaffold returns the content of a new config file with the options.
+func Scaffold(opts InitOptions) ([]byte, error) {
+	layout := scaffold{Files: opts.Files}
+	layout.Bump.Commit = opts.Commit
+	layout.Bump.GitTag = opts.GitTag
+	layout.Check.BaseBranch = opts.BaseBranch
+
+	content, err := toml.Marshal(layout)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling config: %w", err)
+	}
+
+	return append([]byte("#:schema "+schemaURL+"\n\n"), content...), nil
+}
+
+// WriteFile writes the content to a new config file, returning
+// ErrConfigFileExists rather than replacing an existing file unless
+// overwrite is set.
+func WriteFile(file string, content []byte, overwrite bool) error {
+	flags := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if overwrite {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+
+	// Config files are committed with the project, so are readable by everyone.
+	//nolint:gosec
+	configFile, err := os.OpenFile(filepath.Clean(file), flags, 0o644)
+	if errors.Is(err, os.ErrExist) {
+		return fmt.Errorf("%w: %s", ErrConfigFileExists, file)
+	}
+
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrWritingConfigFile, err)
+	}
+
+	if _, err := configFile.Write(content); err != nil {
+		_ = configFile.Close()
+
+		return fmt.Errorf("%w: %w", ErrWritingConfigFile, err)
+	}
+
+	if err := configFile.Close(); err != nil {
+		return fmt.Errorf("%w: %w", ErrWritingConfigFile, err)
+	}
+
+	return nil
+}
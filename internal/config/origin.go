@@ -0,0 +1,129 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// OriginDefault is the origin of the config options that weren't set by a
+// config file or flag.
+const OriginDefault = "default"
+
+// Origins maps the dotted key of each config option, e.g. bump.commit, to where
+// its value came from: the path of a config file or the flag that set it.
+// Options of tables keyed by name, like anchors, have a key per entry, e.g.
+// anchors.Dockerfile.
+type Origins map[string]string
+
+// Of returns where the value of the config option with the key came from.
+func (o Origins) Of(key string) string {
+	if origin, ok := o[key]; ok {
+		return origin
+	}
+
+	return OriginDefault
+}
+
+// Setting is the value of a single config option.
+type Setting struct {
+	// Key is the dotted key of the option, see Origins.
+	Key string
+	// Value is the option value formatted as TOML.
+	Value string
+}
+
+// Settings returns every option of the config with its value, sorted by key.
+func Settings(conf Config) ([]Setting, error) {
+	content, err := toml.Marshal(conf)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling config: %w", err)
+	}
+
+	table := map[string]any{}
+	if err := toml.Unmarshal(content, &table); err != nil {
+		return nil, fmt.Errorf("error unmarshalling config: %w", err)
+	}
+
+	values := flatten("", table)
+	settings := make([]Setting, 0, len(values))
+
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		settings = append(settings, Setting{Key: key, Value: formatValue(values[key])})
+	}
+
+	return settings, nil
+}
+
+// flagOrigin is the origin of config options set by the flag.
+func flagOrigin(flag string) string {
+	return "flag --" + flag
+}
+
+// envOrigin is the origin of config options set by the environment variable.
+func envOrigin(name string) string {
+	return "env " + name
+}
+
+// configOrigin returns the config file or environment variable that set the
+// option with the key, or any option in the table with the key, or an empty
+// string if they were all set by flags or left at their default.
+func (o Origins) configOrigin(key string) string {
+	for _, option := range slices.Sorted(maps.Keys(o)) {
+		if option != key && !strings.HasPrefix(option, key+".") {
+			continue
+		}
+
+		if origin := o[option]; !strings.HasPrefix(origin, flagOrigin("")) {
+			return origin
+		}
+	}
+
+	return ""
+}
+
+// flatten returns the values of the table keyed by their dotted key, with the
+// prefix, nested tables are flattened into the keys of their values.
+func flatten(prefix string, table map[string]any) map[string]any {
+	values := map[string]any{}
+
+	for key, value := range table {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		nested, ok := value.(map[string]any)
+		if !ok {
+			values[key] = value
+
+			continue
+		}
+
+		maps.Copy(values, flatten(key, nested))
+	}
+
+	return values
+}
+
+// formatValue formats a value decoded from TOML as TOML.
+func formatValue(value any) string {
+	switch typed := value.(type) {
+	case string:
+		return strconv.Quote(typed)
+
+	case []any:
+		items := make([]string, 0, len(typed))
+		for _, item := range typed {
+			items = append(items, formatValue(item))
+		}
+
+		return "[" + strings.Join(items, ", ") + "]"
+
+	default:
+		return fmt.Sprint(typed)
+	}
+}
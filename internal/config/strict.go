@@ -0,0 +1,226 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// typeMismatch matches the go-toml error for a value of the wrong type, which
+// names the Go struct field, capturing the TOML and Go types.
+//
+//nolint:gochecknoglobals
+var typeMismatch = regexp.MustCompile(`^cannot decode TOML (\w+) into .* of type (\S+)$`)
+
+// tomlTypes are the TOML names of the Go types of the config options.
+//
+//nolint:gochecknoglobals
+var tomlTypes = map[string]string{
+	"bool":                "boolean",
+	"int":                 "integer",
+	"string":              "string",
+	"[]string":            "array of strings",
+	"map[string][]string": "table of arrays",
+}
+
+// Problem is an issue found in a config file by ValidateFile.
+type Problem struct {
+	File string
+	// Line and Column are where the problem is in the file, starting at 1, or
+	// 0 when it isn't at a single position, e.g. an invalid color mode.
+	Line    int
+	Column  int
+	Key     string
+	Message string
+}
+
+// String formats the problem as file:line:column: message, the format most
+// editors and CI systems link to the position in the file.
+func (p Problem) String() string {
+	position := p.File
+	if p.Line > 0 {
+		position += ":" + strconv.Itoa(p.Line) + ":" + strconv.Itoa(p.Column)
+	}
+
+	return position + ": " + p.Message
+}
+
+// ValidateFile checks the config file, returning a problem for every unknown
+// key, e.g. a typo such as comit, for an option with the wrong type and for
+// every option with an invalid value. Loading the config ignores unknown keys,
+// so this is the only way they are reported.
+// An error is only returned if the file can't be read.
+func ValidateFile(file string) ([]Problem, error) {
+	content, err := os.ReadFile(filepath.Clean(file))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrReadingConfigFile, err)
+	}
+
+	// Decoding stops at the first error, so the options with the wrong type and
+	// the unknown keys are found separately, to report both kinds of problem.
+	conf, problems, err := decodeLeniently(file, content)
+	if err != nil {
+		var decodeErr *toml.DecodeError
+		if errors.As(err, &decodeErr) {
+			// Invalid TOML, none of the file can be checked.
+			return []Problem{decodeProblem(file, decodeErr, "")}, nil
+		}
+
+		return []Problem{{File: file, Message: err.Error()}}, nil
+	}
+
+	problems = append(problems, unknownKeys(file, content)...)
+
+	for _, invalid := range invalidOptions(conf) {
+		problems = append(problems, Problem{File: file, Key: invalid.key, Message: invalid.err.Error()})
+	}
+
+	return problems, nil
+}
+
+// decodeLeniently decodes the config file over the default config, leaving
+// out every option with a value of the wrong type, and returns a problem for
+// each of them. Only the first of them has its position in the file, as the rest are
+// found by decoding the file again without the options already reported.
+// An error is returned if the file isn't valid TOML.
+func decodeLeniently(file string, content []byte) (Config, []Problem, error) {
+	problems := []Problem{}
+
+	var table map[string]any
+
+	for {
+		conf := Default()
+
+		err := toml.Unmarshal(content, &conf)
+		if err == nil {
+			return conf, problems, nil
+		}
+
+		var decodeErr *toml.DecodeError
+		if !errors.As(err, &decodeErr) || len(decodeErr.Key()) == 0 {
+			//nolint:wrapcheck
+			return Config{}, nil, err
+		}
+
+		problem := decodeProblem(file, decodeErr, "")
+		if table != nil {
+			problem.Line, problem.Column = 0, 0
+		}
+
+		problems = append(problems, problem)
+
+		if table == nil {
+			if err := toml.Unmarshal(content, &table); err != nil {
+				//nolint:wrapcheck
+				return Config{}, nil, err
+			}
+		}
+
+		if !deleteKey(table, decodeErr.Key()) {
+			return conf, problems, nil
+		}
+
+		if content, err = toml.Marshal(table); err != nil {
+			return Config{}, nil, fmt.Errorf("error marshalling config: %w", err)
+		}
+	}
+}
+
+// deleteKey deletes the value with the dotted key, split into its parts, from
+// the table, returning false if there isn't one.
+func deleteKey(table map[string]any, key []string) bool {
+	for _, part := range key[:len(key)-1] {
+		nested, ok := table[part].(map[string]any)
+		if !ok {
+			return false
+		}
+
+		table = nested
+	}
+
+	if _, ok := table[key[len(key)-1]]; !ok {
+		return false
+	}
+
+	delete(table, key[len(key)-1])
+
+	return true
+}
+
+// unknownKeys returns a problem for every key in the config file that isn't a
+// config option.
+func unknownKeys(file string, content []byte) []Problem {
+	decoder := toml.NewDecoder(bytes.NewReader(content))
+	decoder.DisallowUnknownFields()
+
+	var strictErr *toml.StrictMissingError
+	if !errors.As(decoder.Decode(reflect.New(anyValues(reflect.TypeFor[Config]())).Interface()), &strictErr) {
+		return []Problem{}
+	}
+
+	problems := make([]Problem, 0, len(strictErr.Errors))
+	for _, unknown := range strictErr.Errors {
+		problems = append(problems, decodeProblem(file, &unknown, "unknown key "))
+	}
+
+	return problems
+}
+
+// anyValues returns a type with the same keys as the config type, structs
+// and maps, but accepting a value of any type for each option, so decoding
+// into it doesn't stop at a value of the wrong type before finding every
+// unknown key.
+func anyValues(configType reflect.Type) reflect.Type {
+	switch configType.Kind() {
+	case reflect.Struct:
+		fields := make([]reflect.StructField, 0, configType.NumField())
+		for field := range configType.Fields() {
+			fields = append(fields, reflect.StructField{
+				Name: field.Name,
+				Type: anyValues(field.Type),
+				Tag:  field.Tag,
+			})
+		}
+
+		return reflect.StructOf(fields)
+
+	case reflect.Map:
+		return reflect.MapOf(configType.Key(), anyValues(configType.Elem()))
+
+	default:
+		return reflect.TypeFor[any]()
+	}
+}
+
+// decodeProblem converts the error decoding the key into a problem, with the
+// message for unknown keys replaced by the prefix followed by the key.
+func decodeProblem(file string, decodeErr *toml.DecodeError, unknownPrefix string) Problem {
+	line, column := decodeErr.Position()
+	key := strings.Join(decodeErr.Key(), ".")
+
+	message := strings.TrimPrefix(decodeErr.Error(), "toml: ")
+	if match := typeMismatch.FindStringSubmatch(message); match != nil {
+		expected, ok := tomlTypes[match[2]]
+		if !ok {
+			expected = match[2]
+		}
+
+		message = "expected " + expected + ", found " + match[1]
+	}
+
+	if unknownPrefix != "" {
+		message = unknownPrefix + key
+	} else if key != "" {
+		message = "invalid " + key + ": " + message
+	}
+
+	return Problem{File: file, Line: line, Column: column, Key: key, Message: message}
+}
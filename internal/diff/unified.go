@@ -0,0 +1,262 @@
+// Package diff renders unified diffs of version file changes.
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// contextLines is the number of unchanged lines shown around each change,
+// matching the default of diff -u and git diff.
+const contextLines = 3
+
+// opKind is the type of a single line in the edit script.
+type opKind uint8
+
+const (
+	opEqual opKind = iota
+	opDelete
+	opInsert
+)
+
+// op is a single line of the edit script turning before into after.
+type op struct {
+	kind opKind
+	// line is the line from before for equal and delete ops, and from after
+	// for insert ops.
+	line string
+}
+
+// Unified returns a unified diff of the change to the named file, or an empty
+// string when the contents are the same.
+func Unified(name string, before string, after string) string {
+	if before == after {
+		return ""
+	}
+
+	ops := editScript(splitLines(before), splitLines(after))
+
+	var out strings.Builder
+
+	fmt.Fprintf(&out, "--- a/%s\n+++ b/%s\n", name, name)
+
+	for _, h := range hunks(ops) {
+		h.write(&out)
+	}
+
+	return out.String()
+}
+
+// splitLines splits the contents into lines keeping their line endings, so a
+// missing trailing newline or changed line ending shows in the diff.
+func splitLines(contents string) []string {
+	if contents == "" {
+		return nil
+	}
+
+	lines := strings.SplitAfter(contents, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
+
+// editScript returns the shortest edit script turning a into b using Myers'
+// algorithm. Version changes touch very few lines so the trace of the search,
+// which grows with the number of edits, stays small even for large files.
+func editScript(a []string, b []string) []op {
+	n, m := len(a), len(b)
+	maxEdits := n + m
+	offset := maxEdits + 1
+	frontier := make([]int, 2*maxEdits+3)
+	trace := [][]int{}
+
+	for edits := 0; edits <= maxEdits; edits++ {
+		trace = append(trace, append([]int(nil), frontier...))
+
+		for k := -edits; k <= edits; k += 2 {
+			var x int
+			if k == -edits || (k != edits && frontier[offset+k-1] < frontier[offset+k+1]) {
+				x = frontier[offset+k+1]
+			} else {
+				x = frontier[offset+k-1] + 1
+			}
+
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+
+			frontier[offset+k] = x
+
+			if x >= n && y >= m {
+				return backtrack(a, b, trace, offset, edits)
+			}
+		}
+	}
+
+	return nil
+}
+
+// backtrack walks the search trace back from the end of both inputs to build
+// the edit script in order.
+func backtrack(a []string, b []string, trace [][]int, offset int, edits int) []op {
+	x, y := len(a), len(b)
+	ops := []op{}
+
+	for d := edits; d > 0; d-- {
+		frontier := trace[d]
+		k := x - y
+
+		var prevK int
+		if k == -d || (k != d && frontier[offset+k-1] < frontier[offset+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+
+		prevX := frontier[offset+prevK]
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			x--
+			y--
+
+			ops = append(ops, op{kind: opEqual, line: a[x]})
+		}
+
+		if x == prevX {
+			y--
+
+			ops = append(ops, op{kind: opInsert, line: b[y]})
+		} else {
+			x--
+
+			ops = append(ops, op{kind: opDelete, line: a[x]})
+		}
+	}
+
+	for x > 0 && y > 0 {
+		x--
+		y--
+
+		ops = append(ops, op{kind: opEqual, line: a[x]})
+	}
+
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+
+	return ops
+}
+
+// hunk is a run of changes with their surrounding context.
+type hunk struct {
+	beforeStart int
+	afterStart  int
+	ops         []op
+}
+
+// hunks groups the edit script into hunks, merging changes whose context
+// would overlap.
+func hunks(ops []op) []hunk {
+	result := []hunk{}
+	beforeLine, afterLine := 1, 1
+
+	var current *hunk
+
+	lastChange := -1
+
+	for i, o := range ops {
+		if o.kind != opEqual {
+			if current == nil || i-lastChange > 2*contextLines {
+				start := max(i-contextLines, lastChange+1, 0)
+				if current != nil {
+					current.ops = append(
+						current.ops,
+						ops[lastChange+1:lastChange+1+contextLines]...,
+					)
+					result = append(result, *current)
+				}
+
+				lead := i - start
+				current = &hunk{
+					beforeStart: beforeLine - lead,
+					afterStart:  afterLine - lead,
+					ops:         append([]op(nil), ops[start:i]...),
+				}
+			} else {
+				current.ops = append(current.ops, ops[lastChange+1:i]...)
+			}
+
+			current.ops = append(current.ops, o)
+			lastChange = i
+		}
+
+		switch o.kind {
+		case opEqual:
+			beforeLine++
+			afterLine++
+		case opDelete:
+			beforeLine++
+		case opInsert:
+			afterLine++
+		}
+	}
+
+	if current != nil {
+		end := min(lastChange+1+contextLines, len(ops))
+		current.ops = append(current.ops, ops[lastChange+1:end]...)
+		result = append(result, *current)
+	}
+
+	return result
+}
+
+// write renders the hunk header and lines.
+func (h hunk) write(out *strings.Builder) {
+	beforeCount, afterCount := 0, 0
+
+	for _, o := range h.ops {
+		if o.kind != opInsert {
+			beforeCount++
+		}
+
+		if o.kind != opDelete {
+			afterCount++
+		}
+	}
+
+	fmt.Fprintf(
+		out,
+		"@@ -%s +%s @@\n",
+		hunkRange(h.beforeStart, beforeCount),
+		hunkRange(h.afterStart, afterCount),
+	)
+
+	prefixes := map[opKind]string{opEqual: " ", opDelete: "-", opInsert: "+"}
+
+	for _, o := range h.ops {
+		out.WriteString(prefixes[o.kind])
+		out.WriteString(o.line)
+
+		if !strings.HasSuffix(o.line, "\n") {
+			out.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
+
+// hunkRange formats a hunk range the way diff -u does, omitting a count of 1
+// and using the line before the hunk for an empty range.
+func hunkRange(start int, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", start-1)
+	case 1:
+		return fmt.Sprint(start)
+	default:
+		return fmt.Sprintf("%d,%d", start, count)
+	}
+}
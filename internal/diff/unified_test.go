@@ -0,0 +1,100 @@
+package diff_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tx3stn/vrsn/internal/diff"
+)
+
+func TestUnified(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		before   string
+		after    string
+		expected string
+	}{
+		"ReturnsEmptyStringWhenUnchanged": {
+			before:   "1.2.3\n",
+			after:    "1.2.3\n",
+			expected: "",
+		},
+		"ReturnsSingleLineChange": {
+			before: "1.2.3\n",
+			after:  "1.3.0\n",
+			expected: `--- a/VERSION
++++ b/VERSION
+@@ -1 +1 @@
+-1.2.3
++1.3.0
+`,
+		},
+		"IncludesThreeLinesOfContext": {
+			before: "{\n  \"name\": \"vrsn\",\n  \"private\": true,\n  \"license\": \"nah\",\n" +
+				"  \"version\": \"1.0.4\",\n  \"dependencies\": [],\n  \"scripts\": {}\n}\n",
+			after: "{\n  \"name\": \"vrsn\",\n  \"private\": true,\n  \"license\": \"nah\",\n" +
+				"  \"version\": \"1.1.0\",\n  \"dependencies\": [],\n  \"scripts\": {}\n}\n",
+			expected: `--- a/VERSION
++++ b/VERSION
+@@ -2,7 +2,7 @@
+   "name": "vrsn",
+   "private": true,
+   "license": "nah",
+-  "version": "1.0.4",
++  "version": "1.1.0",
+   "dependencies": [],
+   "scripts": {}
+ }
+`,
+		},
+		"SplitsDistantChangesIntoHunks": {
+			before: "a = 1.2.3\nb\nc\nd\ne\nf\ng\nh\ni = 1.2.3\n",
+			after:  "a = 1.3.0\nb\nc\nd\ne\nf\ng\nh\ni = 1.3.0\n",
+			expected: `--- a/VERSION
++++ b/VERSION
+@@ -1,4 +1,4 @@
+-a = 1.2.3
++a = 1.3.0
+ b
+ c
+ d
+@@ -6,4 +6,4 @@
+ f
+ g
+ h
+-i = 1.2.3
++i = 1.3.0
+`,
+		},
+		"MarksMissingTrailingNewline": {
+			before: "1.2.3",
+			after:  "1.3.0",
+			expected: `--- a/VERSION
++++ b/VERSION
+@@ -1 +1 @@
+-1.2.3
+\ No newline at end of file
++1.3.0
+\ No newline at end of file
+`,
+		},
+		"HandlesEmptyBefore": {
+			before: "",
+			after:  "1.3.0\n",
+			expected: `--- a/VERSION
++++ b/VERSION
+@@ -0,0 +1 @@
++1.3.0
+`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, diff.Unified("VERSION", tc.before, tc.after))
+		})
+	}
+}
@@ -1,5 +1,7 @@
 package files
 
+import "strconv"
+
 // Error is the error type.
 type Error uint
 
@@ -51,6 +53,50 @@ const (
 	// android:versionCode attribute can't be found inside an AndroidManifest.xml
 	// file but a version code bump was requested.
 	ErrGettingVersionCodeFromAndroidManifest
+	// ErrGettingVersionFromInfoPlist is the error when the
+	// CFBundleShortVersionString key can't be found inside an Info.plist file.
+	ErrGettingVersionFromInfoPlist
+	// ErrGettingBuildNumberFromInfoPlist is the error when the CFBundleVersion
+	// key can't be found inside an Info.plist file but a build number bump was
+	// requested.
+	ErrGettingBuildNumberFromInfoPlist
+	// ErrGettingVersionFromXcodeProject is the error when the MARKETING_VERSION
+	// build setting can't be found inside a project.pbxproj file.
+	ErrGettingVersionFromXcodeProject
+	// ErrGettingBuildNumberFromXcodeProject is the error when the
+	// CURRENT_PROJECT_VERSION build setting can't be found inside a
+	// project.pbxproj file but a build number bump was requested.
+	ErrGettingBuildNumberFromXcodeProject
+	// ErrGettingVersionFromGoSource is the error when a Version constant or
+	// variable can't be found inside a Go source file.
+	ErrGettingVersionFromGoSource
+	// ErrGettingVersionFromPythonSource is the error when a __version__
+	// attribute can't be found inside a Python source file.
+	ErrGettingVersionFromPythonSource
+	// ErrGettingVersionFromRubySource is the error when a VERSION constant or
+	// version attribute can't be found inside a Ruby source file or gemspec.
+	ErrGettingVersionFromRubySource
+	// ErrGettingVersionFromMixExs is the error when a version keyword or
+	// @version attribute can't be found inside a mix.exs file.
+	ErrGettingVersionFromMixExs
+	// ErrGettingVersionFromAnchors is the error when the version can't be found
+	// after any of the anchors configured for a file.
+	ErrGettingVersionFromAnchors
+	// ErrVersionOccurrencesDoNotMatch is the error when a file that repeats the
+	// version contains different versions in different places.
+	ErrVersionOccurrencesDoNotMatch
+	// ErrParsingLockfile is the error when the lockfile of a version file,
+	// e.g. package-lock.json, can't be parsed to update its version.
+	ErrParsingLockfile
+	// ErrGettingVersionFromPubspec is the error when the top level version
+	// can't be found inside a pubspec.yaml file.
+	ErrGettingVersionFromPubspec
+	// ErrGettingBuildNumberFromPubspec is the error when a build number is
+	// requested but the pubspec.yaml version has no +N build number.
+	ErrGettingBuildNumberFromPubspec
+	// ErrNoBuildNumber is the error when reading the build number of a file
+	// type that doesn't have one, e.g. package.json.
+	ErrNoBuildNumber
 )
 
 // Error returns the error string for the error enum.
@@ -98,7 +144,7 @@ func (e Error) Error() string {
 		return "file is a directory"
 
 	case ErrVersionsDoNotMatch:
-		return "version files do not contain matching versions, run with --verbose to see the version in each file"
+		return "version files do not contain matching versions"
 
 	case ErrGettingVersionFromBazelModule:
 		return "unable to read version from MODULE.bazel"
@@ -109,7 +155,55 @@ func (e Error) Error() string {
 	case ErrGettingVersionCodeFromAndroidManifest:
 		return "unable to read android:versionCode from AndroidManifest.xml"
 
+	case ErrGettingVersionFromInfoPlist:
+		return "unable to read CFBundleShortVersionString from Info.plist"
+
+	case ErrGettingBuildNumberFromInfoPlist:
+		return "unable to read a literal CFBundleVersion from Info.plist"
+
+	case ErrGettingVersionFromXcodeProject:
+		return "unable to read MARKETING_VERSION from project.pbxproj"
+
+	case ErrGettingBuildNumberFromXcodeProject:
+		return "unable to read a literal CURRENT_PROJECT_VERSION from project.pbxproj"
+
+	case ErrGettingVersionFromGoSource:
+		return "unable to read Version from Go source file"
+
+	case ErrGettingVersionFromPythonSource:
+		return "unable to read __version__ from Python source file"
+
+	case ErrGettingVersionFromRubySource:
+		return "unable to read VERSION from Ruby source file"
+
+	case ErrGettingVersionFromMixExs:
+		return "unable to read version from mix.exs"
+
+	case ErrGettingVersionFromAnchors:
+		return "unable to read version after any of the configured anchors"
+
+	case ErrVersionOccurrencesDoNotMatch:
+		return "version occurrences within the file do not match"
+
+	case ErrParsingLockfile:
+		return "unable to parse lockfile to update its version"
+
+	case ErrGettingVersionFromPubspec:
+		return "unable to read version from pubspec.yaml"
+
+	case ErrGettingBuildNumberFromPubspec:
+		return "unable to read a +N build number after the version in pubspec.yaml"
+
+	case ErrNoBuildNumber:
+		return "file type has no build number, only AndroidManifest.xml, Info.plist, project.pbxproj and pubspec.yaml files do"
+
 	default:
 		return "unknown error"
 	}
 }
+
+// Code returns the stable code for the error enum used in machine readable
+// output.
+func (e Error) Code() string {
+	return "files." + strconv.FormatUint(uint64(e), 10)
+}
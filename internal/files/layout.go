@@ -0,0 +1,100 @@
+package files
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// utf8BOM is the byte order mark some Windows editors write at the start of
+// UTF-8 files.
+const utf8BOM = "\ufeff"
+
+// fileLayout records the parts of a version file that aren't line content, so
+// rewriting the file only changes the version characters rather than showing
+// the whole file as changed in a diff.
+type fileLayout struct {
+	bom bool
+	// endings holds the line ending of each line, the last line's ending is
+	// empty when the file has no trailing newline.
+	endings []string
+	// defaultEnding is used for any lines beyond the original ones, and is the
+	// file's first line ending so CRLF files stay CRLF.
+	defaultEnding string
+}
+
+// parseLayout splits the file contents into its layout and the contents
+// normalised to a BOM-less, "\n" separated form for the line scanners.
+func parseLayout(contents string) (string, fileLayout) {
+	layout := fileLayout{defaultEnding: "\n"}
+
+	if trimmed, found := strings.CutPrefix(contents, utf8BOM); found {
+		contents = trimmed
+		layout.bom = true
+	}
+
+	lines := []string{}
+
+	for line := range strings.SplitAfterSeq(contents, "\n") {
+		if line == "" {
+			continue
+		}
+
+		ending := ""
+
+		switch {
+		case strings.HasSuffix(line, "\r\n"):
+			ending = "\r\n"
+		case strings.HasSuffix(line, "\n"):
+			ending = "\n"
+		}
+
+		if ending != "" && len(layout.endings) == 0 {
+			layout.defaultEnding = ending
+		}
+
+		layout.endings = append(layout.endings, ending)
+		lines = append(lines, strings.TrimSuffix(line, ending))
+	}
+
+	return strings.Join(lines, "\n"), layout
+}
+
+// render joins the lines back together using the original layout.
+func (l fileLayout) render(lines []string) string {
+	var rendered strings.Builder
+
+	if l.bom {
+		rendered.WriteString(utf8BOM)
+	}
+
+	for i, line := range lines {
+		rendered.WriteString(line)
+
+		if i < len(l.endings) {
+			rendered.WriteString(l.endings[i])
+		} else {
+			rendered.WriteString(l.defaultEnding)
+		}
+	}
+
+	// The line scanners never return a trailing empty line, so any blank lines
+	// at the end of the file are written back from their endings.
+	for i := len(lines); i < len(l.endings); i++ {
+		rendered.WriteString(l.endings[i])
+	}
+
+	return rendered.String()
+}
+
+// skipBOM drops a leading UTF-8 byte order mark from the reader so it isn't
+// read as part of the version.
+func skipBOM(reader io.Reader) io.Reader {
+	buffered := bufio.NewReader(reader)
+
+	if start, err := buffered.Peek(len(utf8BOM)); err == nil && string(start) == utf8BOM {
+		_, _ = buffered.Discard(len(utf8BOM))
+	}
+
+	return buffered
+}
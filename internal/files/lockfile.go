@@ -0,0 +1,370 @@
+package files
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// lockfileFormat is a lockfile that records the version of the packages in
+// the manifests it locks, so it goes stale when the manifest is bumped.
+type lockfileFormat struct {
+	// names are the file names of the lockfile, in the order the package
+	// manager prefers them.
+	names []string
+	// update returns the contents with the version of the package in the
+	// manifest set to the new version, and false if the lockfile has no entry
+	// for the package.
+	update func(contents string, lockfile string, manifest string, newVersion string) (string, bool, error)
+}
+
+// lookupLockfileFormat returns the lockfile format of the manifest file name.
+// Manifests whose lockfile doesn't record the project's own version, e.g.
+// pyproject.toml and poetry.lock, have no format.
+func lookupLockfileFormat(manifest string) (lockfileFormat, bool) {
+	switch filepath.Base(manifest) {
+	case "package.json":
+		return lockfileFormat{
+			names:  []string{"npm-shrinkwrap.json", "package-lock.json"},
+			update: updateNpmLockfile,
+		}, true
+
+	case "Cargo.toml":
+		return lockfileFormat{
+			names:  []string{"Cargo.lock"},
+			update: updateCargoLockfile,
+		}, true
+
+	default:
+		return lockfileFormat{}, false
+	}
+}
+
+// FindLockfile returns the lockfile that records the version of the package in
+// the manifest, e.g. package-lock.json for package.json or Cargo.lock for
+// Cargo.toml. The directory of the manifest is searched first, then each of
+// its parents up to the root of the git repository, as the packages of a
+// workspace share a single lockfile. The lockfile is returned relative to dir.
+func FindLockfile(dir string, manifest string) (string, bool) {
+	format, ok := lookupLockfileFormat(manifest)
+	if !ok {
+		return "", false
+	}
+
+	searchDir := filepath.Dir(versionFilePath(dir, manifest))
+
+	for {
+		for _, name := range format.names {
+			path := filepath.Join(searchDir, name)
+
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				return relativeTo(dir, path), true
+			}
+		}
+
+		if _, err := os.Stat(filepath.Join(searchDir, ".git")); err == nil {
+			return "", false
+		}
+
+		parent := filepath.Dir(searchDir)
+		if parent == searchDir {
+			return "", false
+		}
+
+		searchDir = parent
+	}
+}
+
+// StageLockfile stages the lockfile with the version of the package in each of
+// the manifests set to the new version, see FindLockfile. It returns false,
+// staging nothing, when the lockfile has no entry for any of the packages.
+func (t *Transaction) StageLockfile(
+	dir string,
+	lockfile string,
+	manifests []string,
+	newVersion string,
+) (bool, error) {
+	format, ok := lookupLockfileFormat(manifests[0])
+	if !ok {
+		return false, nil
+	}
+
+	lockPath := versionFilePath(dir, lockfile)
+
+	return t.stage(dir, lockfile, func(contents string) (string, bool, error) {
+		updated := false
+
+		for _, manifest := range manifests {
+			var (
+				found bool
+				err   error
+			)
+
+			contents, found, err = format.update(contents, lockPath, versionFilePath(dir, manifest), newVersion)
+			if err != nil {
+				return "", false, fmt.Errorf("%w: %s: %w", ErrParsingLockfile, lockfile, err)
+			}
+
+			updated = updated || found
+		}
+
+		return contents, updated, nil
+	})
+}
+
+// updateNpmLockfile sets the version of the package in a package-lock.json or
+// npm-shrinkwrap.json. Packages are keyed by their directory relative to the
+// lockfile, with the root package also holding the top level version. Only
+// the version strings are replaced so the rest of the file is untouched.
+func updateNpmLockfile(contents string, lockfile string, manifest string, newVersion string) (string, bool, error) {
+	key, err := filepath.Rel(filepath.Dir(lockfile), filepath.Dir(manifest))
+	if err != nil {
+		return "", false, fmt.Errorf("error finding package in lockfile: %w", err)
+	}
+
+	key = filepath.ToSlash(key)
+	if key == "." {
+		key = ""
+	}
+
+	paths := [][]string{{"packages", key, "version"}}
+	if key == "" {
+		paths = append(paths, []string{"version"})
+	}
+
+	spans, err := jsonStringSpans(contents, paths)
+	if err != nil {
+		return "", false, err
+	}
+
+	// Replace from the end of the file so the earlier offsets stay valid.
+	slices.SortFunc(spans, func(a, b [2]int) int { return b[0] - a[0] })
+
+	for _, span := range spans {
+		contents = contents[:span[0]] + newVersion + contents[span[1]:]
+	}
+
+	return contents, len(spans) > 0, nil
+}
+
+// jsonFrame is an object or array the JSON decoder is inside of.
+type jsonFrame struct {
+	object bool
+	// key is the key of the current value when in an object.
+	key       string
+	expectKey bool
+}
+
+// jsonStack is the path of objects and arrays to the current JSON token.
+type jsonStack []jsonFrame
+
+// next moves the stack on past the token, returning true when the token is a
+// value rather than a delimiter or an object key.
+func (s *jsonStack) next(token json.Token) bool {
+	stack := *s
+
+	switch token {
+	case json.Delim('{'):
+		*s = append(stack, jsonFrame{object: true, expectKey: true})
+
+		return false
+
+	case json.Delim('['):
+		*s = append(stack, jsonFrame{})
+
+		return false
+
+	case json.Delim('}'), json.Delim(']'):
+		stack = stack[:len(stack)-1]
+		stack.valueDone()
+		*s = stack
+
+		return false
+	}
+
+	if len(stack) > 0 && stack[len(stack)-1].expectKey {
+		stack[len(stack)-1].key, _ = token.(string)
+		stack[len(stack)-1].expectKey = false
+
+		return false
+	}
+
+	stack.valueDone()
+
+	return true
+}
+
+// valueDone marks the value of the current key read, so the next token in an
+// object is a key. The key is kept until then, so the value's path can still
+// be checked.
+func (s jsonStack) valueDone() {
+	if len(s) > 0 && s[len(s)-1].object {
+		s[len(s)-1].expectKey = true
+	}
+}
+
+// at reports whether the current value is at the path of object keys.
+func (s jsonStack) at(path []string) bool {
+	if len(s) != len(path) {
+		return false
+	}
+
+	for i, frame := range s {
+		if !frame.object || frame.key != path[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// jsonStringSpans returns the start and end offsets of the contents of the
+// string values at any of the paths of object keys in the JSON document.
+func jsonStringSpans(contents string, paths [][]string) ([][2]int, error) {
+	decoder := json.NewDecoder(strings.NewReader(contents))
+	stack := jsonStack{}
+	spans := [][2]int{}
+
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) && len(stack) == 0 {
+			return spans, nil
+		}
+
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("error decoding JSON: %w", err)
+		}
+
+		if !stack.next(token) {
+			continue
+		}
+
+		value, ok := token.(string)
+		if !ok || !slices.ContainsFunc(paths, stack.at) {
+			continue
+		}
+
+		// The offset is just after the closing quote, so the value starts its
+		// length before that unless it holds escaped characters, which a
+		// version never does.
+		end := int(decoder.InputOffset()) - 1
+		if start := end - len(value); start > 0 && contents[start:end] == value {
+			spans = append(spans, [2]int{start, end})
+		}
+	}
+}
+
+// cargoLockLine matches the name, version and source lines of a [[package]]
+// in a Cargo.lock, capturing the key and the quoted value.
+//
+//nolint:gochecknoglobals
+var cargoLockLine = regexp.MustCompile(`^(name|version|source)(\s*=\s*")([^"]*)(".*)$`)
+
+// updateCargoLockfile sets the version of the package in a Cargo.lock. The
+// package is the [[package]] with the name from the manifest and no source,
+// as only dependencies from a registry or git have one.
+func updateCargoLockfile(contents string, _ string, manifest string, newVersion string) (string, bool, error) {
+	name, err := cargoPackageName(manifest)
+	if err != nil || name == "" {
+		// A workspace manifest without a package of its own has no entry.
+		return contents, false, err
+	}
+
+	normalised, layout := parseLayout(contents)
+	lines := strings.Split(normalised, "\n")
+
+	type cargoPackage struct {
+		name        string
+		versionLine int
+		hasSource   bool
+	}
+
+	current := cargoPackage{versionLine: -1}
+	found := false
+
+	// finish updates the package that just ended if it is the manifest's.
+	finish := func() {
+		if current.name == name && !current.hasSource && current.versionLine >= 0 && !found {
+			match := cargoLockLine.FindStringSubmatch(lines[current.versionLine])
+			lines[current.versionLine] = match[1] + match[2] + newVersion + match[4]
+			found = true
+		}
+
+		current = cargoPackage{versionLine: -1}
+	}
+
+	for i, line := range lines {
+		if strings.HasPrefix(line, "[") {
+			finish()
+
+			continue
+		}
+
+		match := cargoLockLine.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		switch match[1] {
+		case "name":
+			current.name = match[3]
+		case "version":
+			current.versionLine = i
+		case "source":
+			current.hasSource = true
+		}
+	}
+
+	finish()
+
+	return layout.render(lines), found, nil
+}
+
+// cargoPackageName returns the name of the package in the Cargo.toml, empty
+// for a workspace manifest without a [package] table.
+func cargoPackageName(manifest string) (string, error) {
+	content, err := os.ReadFile(filepath.Clean(manifest))
+	if err != nil {
+		return "", fmt.Errorf("error reading %s: %w", manifest, err)
+	}
+
+	var cargo struct {
+		Package struct {
+			Name string `toml:"name"`
+		} `toml:"package"`
+	}
+
+	if err := toml.Unmarshal(content, &cargo); err != nil {
+		return "", fmt.Errorf("error parsing %s: %w", manifest, err)
+	}
+
+	return cargo.Package.Name, nil
+}
+
+// relativeTo returns the path relative to dir, or the absolute path if it
+// can't be made relative, e.g. on another drive on Windows.
+func relativeTo(dir string, path string) string {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return path
+	}
+
+	rel, err := filepath.Rel(absDir, path)
+	if err != nil {
+		return path
+	}
+
+	return rel
+}
@@ -0,0 +1,254 @@
+package files_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tx3stn/vrsn/internal/files"
+)
+
+const packageLock = `{
+  "name": "app",
+  "version": "1.2.3",
+  "lockfileVersion": 3,
+  "requires": true,
+  "packages": {
+    "": {
+      "name": "app",
+      "version": "1.2.3",
+      "workspaces": ["packages/api"]
+    },
+    "node_modules/left-pad": {
+      "version": "1.2.3"
+    },
+    "packages/api": {
+      "name": "api",
+      "version": "1.2.3"
+    }
+  }
+}
+`
+
+const cargoLock = "# This file is automatically @generated by Cargo.\r\n" +
+	"version = 4\r\n" +
+	"\r\n" +
+	"[[package]]\r\n" +
+	"name = \"app\"\r\n" +
+	"version = \"1.2.3\"\r\n" +
+	"dependencies = [\r\n" +
+	" \"serde\",\r\n" +
+	"]\r\n" +
+	"\r\n" +
+	"[[package]]\r\n" +
+	"name = \"app\"\r\n" +
+	"version = \"1.2.3\"\r\n" +
+	"source = \"registry+https://github.com/rust-lang/crates.io-index\"\r\n"
+
+func TestFindLockfile(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		files            []string
+		manifest         string
+		expectedLockfile string
+		expectedFound    bool
+	}{
+		"FindsLockfileNextToManifest": {
+			files:            []string{"package.json", "package-lock.json"},
+			manifest:         "package.json",
+			expectedLockfile: "package-lock.json",
+			expectedFound:    true,
+		},
+		"PrefersShrinkwrap": {
+			files:            []string{"package.json", "package-lock.json", "npm-shrinkwrap.json"},
+			manifest:         "package.json",
+			expectedLockfile: "npm-shrinkwrap.json",
+			expectedFound:    true,
+		},
+		"FindsWorkspaceLockfileInParent": {
+			files:            []string{"Cargo.lock", "crates/app/Cargo.toml"},
+			manifest:         "crates/app/Cargo.toml",
+			expectedLockfile: "Cargo.lock",
+			expectedFound:    true,
+		},
+		"StopsAtRepositoryRoot": {
+			files:            []string{"repo/.git/HEAD", "repo/package.json", "package-lock.json"},
+			manifest:         "repo/package.json",
+			expectedLockfile: "",
+			expectedFound:    false,
+		},
+		"IgnoresManifestsWithoutLockedVersion": {
+			files:            []string{"pyproject.toml", "poetry.lock"},
+			manifest:         "pyproject.toml",
+			expectedLockfile: "",
+			expectedFound:    false,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			require.NoError(t, os.Mkdir(filepath.Join(dir, ".git"), 0o750))
+
+			for _, file := range tc.files {
+				path := filepath.Join(dir, file)
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+				require.NoError(t, os.WriteFile(path, []byte{}, 0o600))
+			}
+
+			lockfile, found := files.FindLockfile(dir, tc.manifest)
+			assert.Equal(t, tc.expectedFound, found)
+			assert.Equal(t, tc.expectedLockfile, lockfile)
+		})
+	}
+}
+
+func TestTransactionStageLockfile(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		files         map[string]string
+		lockfile      string
+		manifests     []string
+		expectedFound bool
+		expected      string
+		expectedError error
+	}{
+		"UpdatesNpmRootPackage": {
+			files:         map[string]string{"package.json": "{}", "package-lock.json": packageLock},
+			lockfile:      "package-lock.json",
+			manifests:     []string{"package.json"},
+			expectedFound: true,
+			expected: `{
+  "name": "app",
+  "version": "1.3.0",
+  "lockfileVersion": 3,
+  "requires": true,
+  "packages": {
+    "": {
+      "name": "app",
+      "version": "1.3.0",
+      "workspaces": ["packages/api"]
+    },
+    "node_modules/left-pad": {
+      "version": "1.2.3"
+    },
+    "packages/api": {
+      "name": "api",
+      "version": "1.2.3"
+    }
+  }
+}
+`,
+			expectedError: nil,
+		},
+		"UpdatesNpmWorkspacePackage": {
+			files:         map[string]string{"packages/api/package.json": "{}", "package-lock.json": packageLock},
+			lockfile:      "package-lock.json",
+			manifests:     []string{"packages/api/package.json"},
+			expectedFound: true,
+			expected: `{
+  "name": "app",
+  "version": "1.2.3",
+  "lockfileVersion": 3,
+  "requires": true,
+  "packages": {
+    "": {
+      "name": "app",
+      "version": "1.2.3",
+      "workspaces": ["packages/api"]
+    },
+    "node_modules/left-pad": {
+      "version": "1.2.3"
+    },
+    "packages/api": {
+      "name": "api",
+      "version": "1.3.0"
+    }
+  }
+}
+`,
+			expectedError: nil,
+		},
+		"SkipsNpmLockfileWithoutPackage": {
+			files:         map[string]string{"packages/web/package.json": "{}", "package-lock.json": packageLock},
+			lockfile:      "package-lock.json",
+			manifests:     []string{"packages/web/package.json"},
+			expectedFound: false,
+			expected:      packageLock,
+			expectedError: nil,
+		},
+		"ReturnsErrorForInvalidNpmLockfile": {
+			files:         map[string]string{"package.json": "{}", "package-lock.json": "{\n"},
+			lockfile:      "package-lock.json",
+			manifests:     []string{"package.json"},
+			expectedFound: false,
+			expected:      "{\n",
+			expectedError: files.ErrParsingLockfile,
+		},
+		"UpdatesCargoPackageWithoutSource": {
+			files: map[string]string{
+				"Cargo.toml": "[package]\nname = \"app\"\nversion = \"1.2.3\"\n",
+				"Cargo.lock": cargoLock,
+			},
+			lockfile:      "Cargo.lock",
+			manifests:     []string{"Cargo.toml"},
+			expectedFound: true,
+			expected: "# This file is automatically @generated by Cargo.\r\n" +
+				"version = 4\r\n" +
+				"\r\n" +
+				"[[package]]\r\n" +
+				"name = \"app\"\r\n" +
+				"version = \"1.3.0\"\r\n" +
+				"dependencies = [\r\n" +
+				" \"serde\",\r\n" +
+				"]\r\n" +
+				"\r\n" +
+				"[[package]]\r\n" +
+				"name = \"app\"\r\n" +
+				"version = \"1.2.3\"\r\n" +
+				"source = \"registry+https://github.com/rust-lang/crates.io-index\"\r\n",
+			expectedError: nil,
+		},
+		"SkipsCargoWorkspaceManifest": {
+			files: map[string]string{
+				"Cargo.toml": "[workspace.package]\nversion = \"1.2.3\"\n",
+				"Cargo.lock": cargoLock,
+			},
+			lockfile:      "Cargo.lock",
+			manifests:     []string{"Cargo.toml"},
+			expectedFound: false,
+			expected:      cargoLock,
+			expectedError: nil,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			for file, content := range tc.files {
+				path := filepath.Join(dir, file)
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+				require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+			}
+
+			var txn files.Transaction
+
+			found, err := txn.StageLockfile(dir, tc.lockfile, tc.manifests, "1.3.0")
+			require.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, tc.expectedFound, found)
+			require.NoError(t, txn.Commit())
+
+			actual, err := os.ReadFile(filepath.Clean(filepath.Join(dir, tc.lockfile)))
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, string(actual))
+		})
+	}
+}
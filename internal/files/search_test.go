@@ -1,6 +1,7 @@
 package files_test
 
 import (
+	"log/slog"
 	"os"
 	"path/filepath"
 	"testing"
@@ -31,6 +32,11 @@ func TestFind(t *testing.T) {
 			expected:      "testdata/all/foo.txt",
 			expectedError: nil,
 		},
+		"ReturnsFileFlagForSourceFileNotUsedInDiscovery": {
+			fileFlag:      "testdata/all/__init__.py",
+			expected:      "testdata/all/__init__.py",
+			expectedError: nil,
+		},
 		"ReturnsErrorWhenFileFlagDoesNotExist": {
 			fileFlag:      "testdata/single/nope",
 			expected:      "",
@@ -72,7 +78,7 @@ func TestFind(t *testing.T) {
 			finder := files.VersionFileFinder{
 				ErrorOnNoFilesFound: tc.errorOnNoFilesFound,
 				FileFlag:            filepath.FromSlash(tc.fileFlag),
-				Logger:              logger.NewBasic(false, false),
+				Logger:              logger.NewBasic(false, slog.New(slog.DiscardHandler)),
 				SearchDir:           filepath.FromSlash(tc.searchDir),
 			}
 
@@ -106,11 +112,16 @@ func TestGetVersionFilesInDirectory(t *testing.T) {
 				"build.gradle.kts",
 				"Cargo.toml",
 				"CMakeLists.txt",
+				"Info.plist",
+				"mix.exs",
 				"MODULE.bazel",
 				"package.json",
+				"project.pbxproj",
+				"pubspec.yaml",
 				"pyproject.toml",
 				"setup.py",
 				"VERSION",
+				"vrsn.gemspec",
 			},
 		},
 		"ReturnsErrorWhenDirectoryDoesNotExist": {
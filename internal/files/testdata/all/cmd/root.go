@@ -0,0 +1,8 @@
+package cmd
+
+// Version is set at build time.
+var Version = "2.3.1"
+
+func run() string {
+	return "version = 9.9.9"
+}
@@ -0,0 +1,3 @@
+import Config
+
+@version "1.1.0"
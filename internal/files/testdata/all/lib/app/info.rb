@@ -0,0 +1,3 @@
+module App
+  VERSION = "3.0.2"
+end
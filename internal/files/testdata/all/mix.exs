@@ -0,0 +1,17 @@
+defmodule Vrsn.MixProject do
+  use Mix.Project
+
+  @version "0.12.3"
+
+  def project do
+    [
+      app: :vrsn,
+      version: @version,
+      deps: deps()
+    ]
+  end
+
+  defp deps do
+    [{:jason, "~> 1.4"}]
+  end
+end
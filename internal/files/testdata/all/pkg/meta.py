@@ -0,0 +1,4 @@
+"""Package metadata."""
+
+__title__ = "app"
+__version__ = "0.8.4"
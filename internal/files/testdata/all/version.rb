@@ -0,0 +1,5 @@
+# frozen_string_literal: true
+
+module Vrsn
+  VERSION = "1.9.2"
+end
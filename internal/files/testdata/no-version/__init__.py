@@ -0,0 +1,3 @@
+"""vrsn example package."""
+
+__version__ = "unknown"
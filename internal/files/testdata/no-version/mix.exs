@@ -0,0 +1,17 @@
+defmodule Vrsn.MixProject do
+  use Mix.Project
+
+  @version "unknown"
+
+  def project do
+    [
+      app: :vrsn,
+      version: @version,
+      deps: deps()
+    ]
+  end
+
+  defp deps do
+    [{:jason, "~> 1.4"}]
+  end
+end
@@ -0,0 +1,4 @@
+package vrsn
+
+// Version is the library version.
+const Version = "unknown"
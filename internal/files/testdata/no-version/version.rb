@@ -0,0 +1,5 @@
+# frozen_string_literal: true
+
+module Vrsn
+  VERSION = "unknown"
+end
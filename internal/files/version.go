@@ -23,10 +23,23 @@ func newScanner(reader io.Reader) *bufio.Scanner {
 }
 
 type versionFileMatcher struct {
+	// keyMatcher, when set, marks formats where the version is on the line
+	// after its key rather than on the key line itself, e.g. Info.plist's
+	// <key>/<string> pairs. lineMatcher is then only checked on the line
+	// immediately following a keyMatcher match.
+	keyMatcher     func(string) bool
 	lineMatcher    func(string) bool
 	notFoundError  error
 	singleLineFile bool
 	versionRegex   *regexp.Regexp
+	// explicitOnly marks formats whose file names are shared with plenty of
+	// files that don't hold a version (e.g. __init__.py), so they are only
+	// used when passed explicitly and never picked up by directory discovery.
+	explicitOnly bool
+	// replaceAll updates every matching line rather than only the first, for
+	// formats that legitimately repeat the version, e.g. once per build
+	// configuration in an Xcode project.
+	replaceAll bool
 	// secondary describes an additional value updated alongside the primary
 	// version (e.g. android:versionCode). It is nil for the single-field
 	// formats and only applied when a value is supplied to the writer.
@@ -37,9 +50,13 @@ type versionFileMatcher struct {
 // regex must have the same 4-group shape as versionRegex, i.e.
 // (prefix)(key=")(value)(suffix), so the writer can replace the value by index.
 type secondaryField struct {
+	keyMatcher    func(string) bool
 	lineMatcher   func(string) bool
 	notFoundError error
 	regex         *regexp.Regexp
+	// value selects the write option holding the build number for this
+	// field, a nil build number leaves the field untouched.
+	value func(WriteOptions) BuildNumber
 }
 
 // tomlVersionLine matches a version key at the start of the line so
@@ -109,6 +126,164 @@ var androidManifestMatcher = versionFileMatcher{
 		},
 		notFoundError: ErrGettingVersionCodeFromAndroidManifest,
 		regex:         regexp.MustCompile(`(.*)(android:versionCode\s*=\s*")(\d+)(".*)`),
+		value: func(opts WriteOptions) BuildNumber {
+			return opts.AndroidVersionCode
+		},
+	},
+}
+
+// plistStringRegex matches the <string> value following a key in a plist.
+var plistStringRegex = regexp.MustCompile(
+	`(.*)(<string>)(?P<semver>v*\d+\.\d+\.\d+)(</string>.*)`,
+)
+
+// plistBuildNumberRegex only matches literal build numbers so a build setting
+// reference such as $(CURRENT_PROJECT_VERSION) is never overwritten.
+var plistBuildNumberRegex = regexp.MustCompile(`(.*)(<string>)(\d+(?:\.\d+)*)(</string>.*)`)
+
+// infoPlistMatcher extracts the version from the CFBundleShortVersionString
+// key in an Info.plist file. It optionally updates CFBundleVersion when a
+// build number is supplied to the writer.
+var infoPlistMatcher = versionFileMatcher{
+	keyMatcher: func(line string) bool {
+		return strings.Contains(line, "<key>CFBundleShortVersionString</key>")
+	},
+	// Using the regex means a $(MARKETING_VERSION) build setting reference
+	// doesn't count as the version line, so it is never overwritten.
+	lineMatcher:    plistStringRegex.MatchString,
+	notFoundError:  ErrGettingVersionFromInfoPlist,
+	singleLineFile: false,
+	versionRegex:   plistStringRegex,
+	replaceAll:     true,
+	secondary: &secondaryField{
+		keyMatcher: func(line string) bool {
+			return strings.Contains(line, "<key>CFBundleVersion</key>")
+		},
+		lineMatcher:   plistBuildNumberRegex.MatchString,
+		notFoundError: ErrGettingBuildNumberFromInfoPlist,
+		regex:         plistBuildNumberRegex,
+		value: func(opts WriteOptions) BuildNumber {
+			return opts.AppleBuildNumber
+		},
+	},
+}
+
+// xcodeBuildNumberRegex only matches literal build numbers, see
+// plistBuildNumberRegex.
+var xcodeBuildNumberRegex = regexp.MustCompile(
+	`(.*)(CURRENT_PROJECT_VERSION\s*=\s*"?)(\d+(?:\.\d+)*)("?;.*)`,
+)
+
+// xcodeProjectMatcher extracts the version from the MARKETING_VERSION build
+// setting in a project.pbxproj file. The setting is repeated for every build
+// configuration so all of them are updated together. It optionally updates
+// CURRENT_PROJECT_VERSION when a build number is supplied to the writer.
+var xcodeProjectMatcher = versionFileMatcher{
+	lineMatcher: func(line string) bool {
+		return strings.Contains(line, "MARKETING_VERSION")
+	},
+	notFoundError:  ErrGettingVersionFromXcodeProject,
+	singleLineFile: false,
+	versionRegex: regexp.MustCompile(
+		`(.*)(MARKETING_VERSION\s*=\s*"?)(?P<semver>v*\d+\.\d+\.\d+)("?;.*)`,
+	),
+	replaceAll: true,
+	secondary: &secondaryField{
+		lineMatcher:   xcodeBuildNumberRegex.MatchString,
+		notFoundError: ErrGettingBuildNumberFromXcodeProject,
+		regex:         xcodeBuildNumberRegex,
+		value: func(opts WriteOptions) BuildNumber {
+			return opts.AppleBuildNumber
+		},
+	},
+}
+
+// goSourceRegex matches a Version or version constant or variable, with an
+// optional string type, e.g. `const Version = "1.2.3"`.
+var goSourceRegex = regexp.MustCompile(
+	`(.*)(\b[Vv]ersion(?:\s+string)?\s*=\s*")(?P<semver>v*\d+\.\d+\.\d+)(".*)`,
+)
+
+var goSourceMatcher = versionFileMatcher{
+	lineMatcher:    goSourceRegex.MatchString,
+	notFoundError:  ErrGettingVersionFromGoSource,
+	singleLineFile: false,
+	versionRegex:   goSourceRegex,
+	explicitOnly:   true,
+}
+
+// pythonSourceRegex matches the `__version__ = "1.2.3"` module attribute.
+var pythonSourceRegex = regexp.MustCompile(
+	`(.*)(__version__\s*(?::\s*str\s*)?=\s*['"])(?P<semver>v*\d+\.\d+\.\d+)(['"].*)`,
+)
+
+var pythonSourceMatcher = versionFileMatcher{
+	lineMatcher:    pythonSourceRegex.MatchString,
+	notFoundError:  ErrGettingVersionFromPythonSource,
+	singleLineFile: false,
+	versionRegex:   pythonSourceRegex,
+	explicitOnly:   true,
+}
+
+// rubySourceRegex matches a `VERSION = "1.2.3"` constant or a gemspec
+// `spec.version = "1.2.3"` attribute.
+var rubySourceRegex = regexp.MustCompile(
+	`(.*)(\b(?:VERSION|\w+\.version)\s*=\s*['"])(?P<semver>v*\d+\.\d+\.\d+)(['"].*)`,
+)
+
+var rubySourceMatcher = versionFileMatcher{
+	lineMatcher:    rubySourceRegex.MatchString,
+	notFoundError:  ErrGettingVersionFromRubySource,
+	singleLineFile: false,
+	versionRegex:   rubySourceRegex,
+	explicitOnly:   true,
+}
+
+// gemspecMatcher is the discoverable variant of the ruby matcher, a gemspec
+// sits at the project root like any other manifest.
+var gemspecMatcher = versionFileMatcher{
+	lineMatcher:    rubySourceMatcher.lineMatcher,
+	notFoundError:  ErrGettingVersionFromRubySource,
+	singleLineFile: false,
+	versionRegex:   rubySourceMatcher.versionRegex,
+}
+
+// mixExsRegex matches the `version: "1.2.3"` project keyword or the
+// `@version "1.2.3"` module attribute it commonly refers to.
+var mixExsRegex = regexp.MustCompile(
+	`(.*)(\bversion:\s*"|@version\s+")(?P<semver>v*\d+\.\d+\.\d+)(".*)`,
+)
+
+var mixExsMatcher = versionFileMatcher{
+	lineMatcher:    mixExsRegex.MatchString,
+	notFoundError:  ErrGettingVersionFromMixExs,
+	singleLineFile: false,
+	versionRegex:   mixExsRegex,
+}
+
+// pubspecBuildNumberRegex matches the +N build number after the version in a
+// pubspec.yaml, skipping any pre-release suffix, e.g. 1.2.3-beta+4.
+var pubspecBuildNumberRegex = regexp.MustCompile(
+	`^(version:\s*['"]?v*\d+\.\d+\.\d+[^+\s'"]*)(\+)(\d+)(.*)`,
+)
+
+// pubspecMatcher extracts the top level version from a Dart or Flutter
+// pubspec.yaml, keeping any +N build number after it. It optionally updates
+// the build number when one is supplied to the writer.
+var pubspecMatcher = versionFileMatcher{
+	lineMatcher: func(line string) bool {
+		return strings.HasPrefix(line, "version:")
+	},
+	notFoundError:  ErrGettingVersionFromPubspec,
+	singleLineFile: false,
+	versionRegex:   regexp.MustCompile(`^()(version:\s*['"]?)(?P<semver>v*\d+\.\d+\.\d+)(.*)`),
+	secondary: &secondaryField{
+		lineMatcher:   pubspecBuildNumberRegex.MatchString,
+		notFoundError: ErrGettingBuildNumberFromPubspec,
+		regex:         pubspecBuildNumberRegex,
+		value: func(opts WriteOptions) BuildNumber {
+			return opts.PubspecBuildNumber
+		},
 	},
 }
 
@@ -130,6 +305,8 @@ var versionFileMatchers = map[string]versionFileMatcher{
 			`(project\(.*)(VERSION\s+)(?P<semver>v*\d+\.\d+\.\d+)(.*\))`,
 		),
 	},
+	"Info.plist": infoPlistMatcher,
+	"mix.exs":    mixExsMatcher,
 	"package.json": {
 		lineMatcher: func(line string) bool {
 			return strings.Contains(line, `"version":`)
@@ -138,7 +315,9 @@ var versionFileMatchers = map[string]versionFileMatcher{
 		singleLineFile: false,
 		versionRegex:   regexp.MustCompile(`(.*)("version":\s*")(?P<semver>v*\d+\.\d+\.\d+)(".*)`),
 	},
-	"pyproject.toml": tomlMatcher,
+	"project.pbxproj": xcodeProjectMatcher,
+	"pubspec.yaml":    pubspecMatcher,
+	"pyproject.toml":  tomlMatcher,
 	"setup.py": {
 		lineMatcher: func(line string) bool {
 			return strings.Contains(line, `version=`)
@@ -156,6 +335,14 @@ var versionFileMatchers = map[string]versionFileMatcher{
 		singleLineFile: true,
 		versionRegex:   regexp.MustCompile(`(.*)(?P<semver>v*\d+\.\d+\.\d+)(.*)`),
 	},
+	// source files, only used when passed explicitly.
+	"__about__.py":   pythonSourceMatcher,
+	"__init__.py":    pythonSourceMatcher,
+	"__version__.py": pythonSourceMatcher,
+	"_version.py":    pythonSourceMatcher,
+	"version.go":     goSourceMatcher,
+	"version.py":     pythonSourceMatcher,
+	"version.rb":     rubySourceMatcher,
 }
 
 // patternMatchers holds matchers for version files identified by a filename
@@ -167,6 +354,19 @@ var patternMatchers = []struct {
 	matcher versionFileMatcher
 }{
 	{pattern: "AndroidManifest*.xml", matcher: androidManifestMatcher},
+	{pattern: "*-Info.plist", matcher: infoPlistMatcher},
+	{pattern: "*.gemspec", matcher: gemspecMatcher},
+}
+
+// extensionMatchers holds matchers for source files identified by their
+// extension, e.g. cmd/root.go or pkg/__about__.py. They are only consulted for
+// files passed explicitly, when no exact name or pattern matches, as most files
+// with these extensions don't hold the version.
+var extensionMatchers = map[string]versionFileMatcher{
+	".exs": mixExsMatcher,
+	".go":  goSourceMatcher,
+	".py":  pythonSourceMatcher,
+	".rb":  rubySourceMatcher,
 }
 
 // lookupVersionFileMatcher resolves the matcher for a base filename, checking
@@ -185,22 +385,72 @@ func lookupVersionFileMatcher(name string) (versionFileMatcher, bool) {
 	return versionFileMatcher{}, false
 }
 
+// lookupExplicitVersionFileMatcher resolves the matcher for the base filename
+// of a file passed explicitly, falling back to the file's extension when the
+// name isn't a known version file.
+func lookupExplicitVersionFileMatcher(name string) (versionFileMatcher, bool) {
+	if matcher, exists := lookupVersionFileMatcher(name); exists {
+		return matcher, true
+	}
+
+	matcher, exists := extensionMatchers[filepath.Ext(name)]
+
+	return matcher, exists
+}
+
 // getVersionMatcher gets the relevant versionFileMatcher config for the
-// provided input file, falling back to the best effort matcher if there is no
-// config for a file with that name.
-func getVersionMatcher(inputFile string) versionFileMatcher {
+// provided input file, by name then by source file extension, falling back to
+// the best effort matcher if there is no config for the file. Any anchors replace the version matching
+// of the file, see anchoredMatcher.
+func getVersionMatcher(inputFile string, anchors []string) versionFileMatcher {
 	// Split dir and file to support relative paths provided with `--file` CLI flag.
 	_, file := filepath.Split(inputFile)
 
-	matcher, exists := lookupVersionFileMatcher(file)
+	matcher, exists := lookupExplicitVersionFileMatcher(file)
 	if !exists {
-		return bestEffortMatcher
+		matcher = bestEffortMatcher
+	}
+
+	if len(anchors) > 0 {
+		return anchoredMatcher(matcher, anchors)
 	}
 
 	return matcher
 }
 
+// anchoredMatcher matches the version directly after any of the anchors,
+// updating every occurrence and requiring them all to agree. Anchors are
+// literal text, e.g. `ARG VERSION=`, optionally followed by a quote.
+// Any secondary field of the file's usual matcher is kept.
+func anchoredMatcher(base versionFileMatcher, anchors []string) versionFileMatcher {
+	quoted := make([]string, 0, len(anchors))
+	for _, anchor := range anchors {
+		quoted = append(quoted, regexp.QuoteMeta(anchor))
+	}
+
+	anchorRegex := regexp.MustCompile(fmt.Sprintf(
+		`(.*?)((?:%s)\s*['"]?)(?P<semver>v*\d+\.\d+\.\d+)(.*)`,
+		strings.Join(quoted, "|"),
+	))
+
+	return versionFileMatcher{
+		lineMatcher:    anchorRegex.MatchString,
+		notFoundError:  ErrGettingVersionFromAnchors,
+		singleLineFile: false,
+		versionRegex:   anchorRegex,
+		replaceAll:     true,
+		secondary:      base.secondary,
+	}
+}
+
+// getVersion returns the first version in the file. Formats that repeat the
+// version are read in full so an ErrVersionOccurrencesDoNotMatch error is
+// returned when the occurrences disagree, rather than updating them all to a
+// version only some of them had.
 func (v versionFileMatcher) getVersion(scanner *bufio.Scanner) (string, error) {
+	afterKey := false
+	found := ""
+
 	for scanner.Scan() {
 		lineText := scanner.Text()
 
@@ -212,21 +462,99 @@ func (v versionFileMatcher) getVersion(scanner *bufio.Scanner) (string, error) {
 			return lineText, nil
 		}
 
-		if v.lineMatcher(lineText) {
-			semver, found := v.extractVersion(lineText)
-			if !found {
+		if v.isVersionLine(lineText, afterKey) {
+			semver, ok := v.extractVersion(lineText)
+			if !ok {
 				return "", v.notFoundError
 			}
 
-			return semver, nil
+			if !v.replaceAll {
+				return semver, nil
+			}
+
+			if found != "" && semver != found {
+				return "", fmt.Errorf(
+					"%w: found %s and %s",
+					ErrVersionOccurrencesDoNotMatch,
+					found,
+					semver,
+				)
+			}
+
+			found = semver
 		}
+
+		afterKey = v.keyMatcher != nil && v.keyMatcher(lineText)
 	}
 
 	if err := scanner.Err(); err != nil {
 		return "", fmt.Errorf("error reading version file: %w", err)
 	}
 
-	return "", v.notFoundError
+	if found == "" {
+		return "", v.notFoundError
+	}
+
+	return found, nil
+}
+
+// isVersionLine reports whether the line holds the version, afterKey being
+// whether the previous line matched the keyMatcher.
+func (v versionFileMatcher) isVersionLine(lineText string, afterKey bool) bool {
+	if v.keyMatcher != nil && !afterKey {
+		return false
+	}
+
+	return v.lineMatcher(lineText)
+}
+
+// isLine reports whether the line holds the secondary value, afterKey being
+// whether the previous line matched the keyMatcher.
+func (s *secondaryField) isLine(lineText string, afterKey bool) bool {
+	if s.keyMatcher != nil && !afterKey {
+		return false
+	}
+
+	return s.lineMatcher(lineText)
+}
+
+// update replaces the build number on the line with the one the build number
+// returns for it.
+func (s *secondaryField) update(lineText string, buildNumber BuildNumber) (string, error) {
+	match := s.regex.FindStringSubmatch(lineText)
+	if match == nil {
+		return lineText, nil
+	}
+
+	value, err := buildNumber(match[3])
+	if err != nil {
+		return "", fmt.Errorf("error getting build number: %w", err)
+	}
+
+	return s.regex.ReplaceAllString(lineText, fmt.Sprintf(`${1}${2}%s${4}`, value)), nil
+}
+
+// getValue returns the first secondary value in the file.
+func (s *secondaryField) getValue(scanner *bufio.Scanner) (string, error) {
+	afterKey := false
+
+	for scanner.Scan() {
+		lineText := scanner.Text()
+
+		if s.isLine(lineText, afterKey) {
+			if match := s.regex.FindStringSubmatch(lineText); match != nil {
+				return match[3], nil
+			}
+		}
+
+		afterKey = s.keyMatcher != nil && s.keyMatcher(lineText)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("error reading version file: %w", err)
+	}
+
+	return "", s.notFoundError
 }
 
 // extractVersion pulls the semver capture group out of the version line.
@@ -253,19 +581,30 @@ func (v versionFileMatcher) updateVersionInPlace(
 	}
 
 	// The secondary field is only updated when the matcher defines one and a
-	// value is supplied, so ordinary formats are unaffected.
-	updateSecondary := v.secondary != nil && opts.AndroidVersionCode != ""
+	// build number is supplied, so ordinary formats are unaffected.
+	var buildNumber BuildNumber
+	if v.secondary != nil {
+		buildNumber = v.secondary.value(opts)
+	}
+
+	updateSecondary := buildNumber != nil
 	foundVersion := false
 	foundSecondary := false
+	afterVersionKey := false
+	afterSecondaryKey := false
 	allLines := []string{}
 
 	for scanner.Scan() {
 		lineText := scanner.Text()
-
-		// Only replace the first matching line, mirroring getVersion which
-		// reads the first match. Later matches can be unrelated, e.g.
-		// dependency version constraints in Cargo.toml or pyproject.toml.
-		if !foundVersion && v.lineMatcher(lineText) {
+		isVersionKey := v.keyMatcher != nil && v.keyMatcher(lineText)
+		isSecondaryKey := updateSecondary && v.secondary.keyMatcher != nil &&
+			v.secondary.keyMatcher(lineText)
+
+		// Only replace the first matching line unless the format repeats the
+		// version, mirroring getVersion which reads the first match. Later
+		// matches can be unrelated, e.g. dependency version constraints in
+		// Cargo.toml or pyproject.toml.
+		if (v.replaceAll || !foundVersion) && v.isVersionLine(lineText, afterVersionKey) {
 			lineText = v.versionRegex.ReplaceAllString(
 				lineText,
 				fmt.Sprintf(`${1}${2}%s${4}`, opts.NewVersion),
@@ -275,14 +614,19 @@ func (v versionFileMatcher) updateVersionInPlace(
 
 		// The secondary value (e.g. android:versionCode) may share the version
 		// line or be on its own, so it is checked independently of the primary.
-		if updateSecondary && !foundSecondary && v.secondary.lineMatcher(lineText) {
-			lineText = v.secondary.regex.ReplaceAllString(
-				lineText,
-				fmt.Sprintf(`${1}${2}%s${4}`, opts.AndroidVersionCode),
-			)
+		if updateSecondary && (v.replaceAll || !foundSecondary) &&
+			v.secondary.isLine(lineText, afterSecondaryKey) {
+			updated, err := v.secondary.update(lineText, buildNumber)
+			if err != nil {
+				return []string{}, err
+			}
+
+			lineText = updated
 			foundSecondary = true
 		}
 
+		afterVersionKey = isVersionKey
+		afterSecondaryKey = isSecondaryKey
 		allLines = append(allLines, lineText)
 	}
 
@@ -8,9 +8,18 @@ import (
 	"strings"
 )
 
+// ReadOptions carries the per file settings used when reading a version file.
+type ReadOptions struct {
+	// Anchors, when non-empty, replaces the file's usual version matching
+	// with the text preceding each occurrence of the version, for files that
+	// repeat it (e.g. `ARG VERSION=` and `LABEL version="` in a Dockerfile).
+	// Every occurrence must contain the same version.
+	Anchors []string
+}
+
 // GetVersionFromFile reads the version file and returns the semantic
 // version contained.
-func GetVersionFromFile(dir string, inputFile string) (string, error) {
+func GetVersionFromFile(dir string, inputFile string, opts ReadOptions) (string, error) {
 	file, err := os.Open(filepath.Clean(versionFilePath(dir, inputFile)))
 	if err != nil {
 		return "", fmt.Errorf("error opening version file: %w", err)
@@ -21,22 +30,45 @@ func GetVersionFromFile(dir string, inputFile string) (string, error) {
 		_ = file.Close()
 	}()
 
-	return getVersionFromReader(inputFile, file)
+	return getVersionFromReader(inputFile, file, opts)
 }
 
 // GetVersionFromString handles extracting the version from a file that has
 // already been read and is passed as a string such as when getting the
 // contents of a file from a git branch.
-func GetVersionFromString(fileName string, input string) (string, error) {
-	return getVersionFromReader(fileName, strings.NewReader(input))
+func GetVersionFromString(fileName string, input string, opts ReadOptions) (string, error) {
+	return getVersionFromReader(fileName, strings.NewReader(input), opts)
 }
 
 // getVersionFromReader extracts the version from the reader using the
 // matcher config for the provided file name.
-func getVersionFromReader(fileName string, reader io.Reader) (string, error) {
-	matcher := getVersionMatcher(fileName)
+func getVersionFromReader(fileName string, reader io.Reader, opts ReadOptions) (string, error) {
+	matcher := getVersionMatcher(fileName, opts.Anchors)
+
+	return matcher.getVersion(newScanner(skipBOM(reader)))
+}
+
+// GetBuildNumberFromFile reads the build number stored alongside the version,
+// e.g. android:versionCode in an AndroidManifest file or the +N after the
+// version in a pubspec.yaml. ErrNoBuildNumber is returned for file types that
+// don't have one.
+func GetBuildNumberFromFile(dir string, inputFile string) (string, error) {
+	matcher := getVersionMatcher(inputFile, nil)
+	if matcher.secondary == nil {
+		return "", fmt.Errorf("%w: %s", ErrNoBuildNumber, inputFile)
+	}
+
+	file, err := os.Open(filepath.Clean(versionFilePath(dir, inputFile)))
+	if err != nil {
+		return "", fmt.Errorf("error opening version file: %w", err)
+	}
+
+	// The file is only read so a close error can't affect the result.
+	defer func() {
+		_ = file.Close()
+	}()
 
-	return matcher.getVersion(newScanner(reader))
+	return matcher.secondary.getValue(newScanner(skipBOM(file)))
 }
 
 // versionFilePath resolves the path to the version file, supporting absolute
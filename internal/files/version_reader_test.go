@@ -37,6 +37,114 @@ func TestGetVersionFromFile(t *testing.T) {
 			expectedError: files.ErrGettingVersionFromAndroidManifest,
 			expected:      "",
 		},
+		"ReturnsVersionFromInfoPlist": {
+			parentDir:     "all",
+			inputFile:     "Info.plist",
+			expectedError: nil,
+			expected:      "3.1.4",
+		},
+		"ReturnsErrorFromInvalidInfoPlist": {
+			parentDir:     "no-version",
+			inputFile:     "Info.plist",
+			expectedError: files.ErrGettingVersionFromInfoPlist,
+			expected:      "",
+		},
+		"ReturnsVersionFromXcodeProject": {
+			parentDir:     "all",
+			inputFile:     "project.pbxproj",
+			expectedError: nil,
+			expected:      "1.5.0",
+		},
+		"ReturnsErrorFromInvalidXcodeProject": {
+			parentDir:     "no-version",
+			inputFile:     "project.pbxproj",
+			expectedError: files.ErrGettingVersionFromXcodeProject,
+			expected:      "",
+		},
+		"ReturnsVersionFromGoSource": {
+			parentDir:     "all",
+			inputFile:     "version.go",
+			expectedError: nil,
+			expected:      "4.2.0",
+		},
+		"ReturnsErrorFromInvalidGoSource": {
+			parentDir:     "no-version",
+			inputFile:     "version.go",
+			expectedError: files.ErrGettingVersionFromGoSource,
+			expected:      "",
+		},
+		"ReturnsVersionFromPythonSource": {
+			parentDir:     "all",
+			inputFile:     "__init__.py",
+			expectedError: nil,
+			expected:      "0.7.1",
+		},
+		"ReturnsErrorFromInvalidPythonSource": {
+			parentDir:     "no-version",
+			inputFile:     "__init__.py",
+			expectedError: files.ErrGettingVersionFromPythonSource,
+			expected:      "",
+		},
+		"ReturnsVersionFromRubySource": {
+			parentDir:     "all",
+			inputFile:     "version.rb",
+			expectedError: nil,
+			expected:      "1.9.2",
+		},
+		"ReturnsErrorFromInvalidRubySource": {
+			parentDir:     "no-version",
+			inputFile:     "version.rb",
+			expectedError: files.ErrGettingVersionFromRubySource,
+			expected:      "",
+		},
+		"ReturnsVersionFromGoSourceByExtension": {
+			parentDir:     "all",
+			inputFile:     "cmd/root.go",
+			expectedError: nil,
+			expected:      "2.3.1",
+		},
+		"ReturnsVersionFromPythonSourceByExtension": {
+			parentDir:     "all",
+			inputFile:     "pkg/meta.py",
+			expectedError: nil,
+			expected:      "0.8.4",
+		},
+		"ReturnsVersionFromRubySourceByExtension": {
+			parentDir:     "all",
+			inputFile:     "lib/app/info.rb",
+			expectedError: nil,
+			expected:      "3.0.2",
+		},
+		"ReturnsVersionFromElixirSourceByExtension": {
+			parentDir:     "all",
+			inputFile:     "config.exs",
+			expectedError: nil,
+			expected:      "1.1.0",
+		},
+		"ReturnsVersionFromGemspec": {
+			parentDir:     "all",
+			inputFile:     "vrsn.gemspec",
+			expectedError: nil,
+			expected:      "1.9.2",
+		},
+		"ReturnsErrorFromInvalidGemspec": {
+			parentDir:     "no-version",
+			inputFile:     "vrsn.gemspec",
+			expectedError: files.ErrGettingVersionFromRubySource,
+			expected:      "",
+		},
+		"ReturnsVersionFromMixExs": {
+			parentDir:     "all",
+			inputFile:     "mix.exs",
+			expectedError: nil,
+			expected:      "0.12.3",
+		},
+		"ReturnsErrorFromInvalidMixExs": {
+			parentDir:     "no-version",
+			inputFile:     "mix.exs",
+			expectedError: files.ErrGettingVersionFromMixExs,
+			expected:      "",
+		},
 		"ReturnsVersionFromSingleQuotedFileWithBestEffort": {
 			parentDir:     "all",
 			inputFile:     "version.ts",
@@ -115,6 +223,18 @@ func TestGetVersionFromFile(t *testing.T) {
 			expectedError: files.ErrGettingVersionFromTOML,
 			expected:      "",
 		},
+		"ReturnsVersionFromPubspecYAML": {
+			parentDir:     "all",
+			inputFile:     "pubspec.yaml",
+			expectedError: nil,
+			expected:      "1.4.2",
+		},
+		"ReturnsErrorFromInvalidPubspecYAML": {
+			parentDir:     "no-version",
+			inputFile:     "pubspec.yaml",
+			expectedError: files.ErrGettingVersionFromPubspec,
+			expected:      "",
+		},
 		"ReturnsVersionFromCMakeLists": {
 			parentDir:     "all",
 			inputFile:     "CMakeLists.txt",
@@ -184,7 +304,7 @@ func TestGetVersionFromFile(t *testing.T) {
 			t.Parallel()
 
 			dir := filepath.Join("testdata", tc.parentDir)
-			actual, err := files.GetVersionFromFile(dir, tc.inputFile)
+			actual, err := files.GetVersionFromFile(dir, tc.inputFile, files.ReadOptions{})
 
 			require.ErrorIs(t, err, tc.expectedError)
 			assert.Equal(t, tc.expected, actual)
@@ -399,6 +519,132 @@ func TestGetVersionFromString(t *testing.T) {
 			expectedError: files.ErrGettingVersionFromAndroidManifest,
 			expected:      "",
 		},
+		"ReturnsVersionFromInfoPlist": {
+			parentDir:     "all",
+			inputFile:     "Info.plist",
+			expectedError: nil,
+			expected:      "3.1.4",
+		},
+		"ReturnsErrorFromInvalidInfoPlist": {
+			parentDir:     "no-version",
+			inputFile:     "Info.plist",
+			expectedError: files.ErrGettingVersionFromInfoPlist,
+			expected:      "",
+		},
+		"ReturnsVersionFromXcodeProject": {
+			parentDir:     "all",
+			inputFile:     "project.pbxproj",
+			expectedError: nil,
+			expected:      "1.5.0",
+		},
+		"ReturnsErrorFromInvalidXcodeProject": {
+			parentDir:     "no-version",
+			inputFile:     "project.pbxproj",
+			expectedError: files.ErrGettingVersionFromXcodeProject,
+			expected:      "",
+		},
+		"ReturnsVersionWithPrefixFromInfoPlist": {
+			parentDir:     "prefixed",
+			inputFile:     "Info.plist",
+			expectedError: nil,
+			expected:      "v3.1.3",
+		},
+		"ReturnsVersionWithPrefixFromXcodeProject": {
+			parentDir:     "prefixed",
+			inputFile:     "project.pbxproj",
+			expectedError: nil,
+			expected:      "v1.4.9",
+		},
+		"ReturnsVersionFromGoSource": {
+			parentDir:     "all",
+			inputFile:     "version.go",
+			expectedError: nil,
+			expected:      "4.2.0",
+		},
+		"ReturnsErrorFromInvalidGoSource": {
+			parentDir:     "no-version",
+			inputFile:     "version.go",
+			expectedError: files.ErrGettingVersionFromGoSource,
+			expected:      "",
+		},
+		"ReturnsVersionWithPrefixFromGoSource": {
+			parentDir:     "prefixed",
+			inputFile:     "version.go",
+			expectedError: nil,
+			expected:      "v4.2.0",
+		},
+		"ReturnsVersionFromPythonSource": {
+			parentDir:     "all",
+			inputFile:     "__init__.py",
+			expectedError: nil,
+			expected:      "0.7.1",
+		},
+		"ReturnsErrorFromInvalidPythonSource": {
+			parentDir:     "no-version",
+			inputFile:     "__init__.py",
+			expectedError: files.ErrGettingVersionFromPythonSource,
+			expected:      "",
+		},
+		"ReturnsVersionWithPrefixFromPythonSource": {
+			parentDir:     "prefixed",
+			inputFile:     "__init__.py",
+			expectedError: nil,
+			expected:      "v0.7.1",
+		},
+		"ReturnsVersionFromRubySource": {
+			parentDir:     "all",
+			inputFile:     "version.rb",
+			expectedError: nil,
+			expected:      "1.9.2",
+		},
+		"ReturnsErrorFromInvalidRubySource": {
+			parentDir:     "no-version",
+			inputFile:     "version.rb",
+			expectedError: files.ErrGettingVersionFromRubySource,
+			expected:      "",
+		},
+		"ReturnsVersionWithPrefixFromRubySource": {
+			parentDir:     "prefixed",
+			inputFile:     "version.rb",
+			expectedError: nil,
+			expected:      "v1.9.2",
+		},
+		"ReturnsVersionFromGemspec": {
+			parentDir:     "all",
+			inputFile:     "vrsn.gemspec",
+			expectedError: nil,
+			expected:      "1.9.2",
+		},
+		"ReturnsErrorFromInvalidGemspec": {
+			parentDir:     "no-version",
+			inputFile:     "vrsn.gemspec",
+			expectedError: files.ErrGettingVersionFromRubySource,
+			expected:      "",
+		},
+		"ReturnsVersionWithPrefixFromGemspec": {
+			parentDir:     "prefixed",
+			inputFile:     "vrsn.gemspec",
+			expectedError: nil,
+			expected:      "v1.9.2",
+		},
+		"ReturnsVersionFromMixExs": {
+			parentDir:     "all",
+			inputFile:     "mix.exs",
+			expectedError: nil,
+			expected:      "0.12.3",
+		},
+		"ReturnsErrorFromInvalidMixExs": {
+			parentDir:     "no-version",
+			inputFile:     "mix.exs",
+			expectedError: files.ErrGettingVersionFromMixExs,
+			expected:      "",
+		},
+		"ReturnsVersionWithPrefixFromMixExs": {
+			parentDir:     "prefixed",
+			inputFile:     "mix.exs",
+			expectedError: nil,
+			expected:      "v0.12.3",
+		},
 		"ReturnsVersionWithPrefixFromAndroidManifest": {
 			parentDir:     "prefixed",
 			inputFile:     "AndroidManifest.xml",
@@ -416,8 +662,123 @@ func TestGetVersionFromString(t *testing.T) {
 			content, err := os.ReadFile(filepath.Join("testdata", tc.parentDir, tc.inputFile))
 			require.NoError(t, err)
 
-			actual, err := files.GetVersionFromString(tc.inputFile, string(content))
+			actual, err := files.GetVersionFromString(
+				tc.inputFile,
+				string(content),
+				files.ReadOptions{},
+			)
+
+			require.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+// TestGetVersionFromStringChecksRepeatedVersionsAgree checks every occurrence
+// is read for files that repeat the version, via anchors or a format that
+// repeats it, and that a mismatch is an error rather than the first match.
+func TestGetVersionFromStringChecksRepeatedVersionsAgree(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		fileName      string
+		content       string
+		anchors       []string
+		expectedError error
+		expected      string
+	}{
+		"ReturnsVersionWhenAnchoredOccurrencesMatch": {
+			fileName:      "Dockerfile",
+			content:       "FROM scratch\nARG VERSION=1.2.3\nLABEL version=\"1.2.3\"\n",
+			anchors:       []string{"ARG VERSION=", "LABEL version="},
+			expectedError: nil,
+			expected:      "1.2.3",
+		},
+		"ReturnsErrorWhenAnchoredOccurrencesDiffer": {
+			fileName:      "Dockerfile",
+			content:       "FROM scratch\nARG VERSION=1.2.3\nLABEL version=\"1.2.2\"\n",
+			anchors:       []string{"ARG VERSION=", "LABEL version="},
+			expectedError: files.ErrVersionOccurrencesDoNotMatch,
+			expected:      "",
+		},
+		"ReturnsErrorWhenNoAnchorMatches": {
+			fileName:      "Dockerfile",
+			content:       "FROM scratch\n",
+			anchors:       []string{"ARG VERSION="},
+			expectedError: files.ErrGettingVersionFromAnchors,
+			expected:      "",
+		},
+		"AnchorsReplaceNativeMatching": {
+			fileName:      "Chart.yaml",
+			content:       "apiVersion: v2\nversion: 0.4.0\nappVersion: \"0.4.0\"\n",
+			anchors:       []string{"version:", "appVersion:"},
+			expectedError: nil,
+			expected:      "0.4.0",
+		},
+		"ReturnsErrorWhenXcodeBuildConfigurationsDiffer": {
+			fileName:      "project.pbxproj",
+			content:       "MARKETING_VERSION = 1.2.3;\nMARKETING_VERSION = 1.2.4;\n",
+			anchors:       nil,
+			expectedError: files.ErrVersionOccurrencesDoNotMatch,
+			expected:      "",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := files.GetVersionFromString(
+				tc.fileName,
+				tc.content,
+				files.ReadOptions{Anchors: tc.anchors},
+			)
+			require.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestGetBuildNumberFromFile(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		parentDir     string
+		inputFile     string
+		expectedError error
+		expected      string
+	}{
+		"ReturnsVersionCodeFromAndroidManifest": {
+			parentDir:     "all",
+			inputFile:     "AndroidManifest.xml",
+			expectedError: nil,
+			expected:      "21401",
+		},
+		"ReturnsBuildNumberFromPubspecYAML": {
+			parentDir:     "all",
+			inputFile:     "pubspec.yaml",
+			expectedError: nil,
+			expected:      "12",
+		},
+		"ReturnsErrorForPubspecYAMLWithoutBuildNumber": {
+			parentDir:     "no-version",
+			inputFile:     "pubspec.yaml",
+			expectedError: files.ErrGettingBuildNumberFromPubspec,
+			expected:      "",
+		},
+		"ReturnsErrorForFileWithoutBuildNumber": {
+			parentDir:     "all",
+			inputFile:     "package.json",
+			expectedError: files.ErrNoBuildNumber,
+			expected:      "",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
 
+			actual, err := files.GetBuildNumberFromFile(filepath.Join("testdata", tc.parentDir), tc.inputFile)
 			require.ErrorIs(t, err, tc.expectedError)
 			assert.Equal(t, tc.expected, actual)
 		})
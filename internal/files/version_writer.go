@@ -1,88 +1,339 @@
 package files
 
 import (
+	"errors"
 	"fmt"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 )
 
+// BuildNumber returns the build number to write alongside the version, given
+// the build number currently in the file, so it can be derived from the new
+// version or increment the current value.
+type BuildNumber func(current string) (string, error)
+
+// FixedBuildNumber returns a BuildNumber that writes the value whatever the
+// current build number is.
+func FixedBuildNumber(value string) BuildNumber {
+	return func(string) (string, error) {
+		return value, nil
+	}
+}
+
 // WriteOptions carries the values written into a version file.
 type WriteOptions struct {
 	// NewVersion is the bumped semantic version written to the primary version
 	// field of every supported file.
 	NewVersion string
-	// AndroidVersionCode, when non-empty, is written to android:versionCode in
-	// AndroidManifest files. Empty leaves versionCode untouched.
-	AndroidVersionCode string
+	// AndroidVersionCode, when set, writes android:versionCode in
+	// AndroidManifest files. Nil leaves versionCode untouched.
+	AndroidVersionCode BuildNumber
+	// AppleBuildNumber, when set, writes CFBundleVersion in Info.plist files
+	// and CURRENT_PROJECT_VERSION in Xcode projects. Nil leaves the build
+	// number untouched.
+	AppleBuildNumber BuildNumber
+	// PubspecBuildNumber, when set, writes the +N build number after the
+	// version in pubspec.yaml files. Nil leaves the build number untouched.
+	PubspecBuildNumber BuildNumber
+	// Anchors, when non-empty, updates the version after each of the anchors
+	// rather than using the file's usual version matching, see ReadOptions.
+	Anchors []string
 }
 
 // WriteVersionToFile updates the version file with the provided new version
 // value.
 // The new contents are written to a temp file which then replaces the
 // original, so a failure part way through never leaves a half written
-// version file behind.
+// version file behind. The file's line endings, byte order mark and trailing
+// newline (or lack of one) are preserved so only the version changes.
 func WriteVersionToFile(dir string, inputFile string, opts WriteOptions) error {
+	var txn Transaction
+
+	if err := txn.Stage(dir, inputFile, opts); err != nil {
+		return err
+	}
+
+	return txn.Commit()
+}
+
+// Transaction writes the new version to several version files as a single
+// unit. Every file is validated and its new contents staged in a temp file
+// before any of them are replaced, and the originals are kept so the files can
+// be restored if a later step fails, such as committing them.
+// The zero value is ready to use.
+type Transaction struct {
+	// DryRun stages the new contents in memory only, so nothing on disk is
+	// touched and Commit is a no-op. Use Changes to see what would be written.
+	DryRun bool
+	writes []stagedWrite
+}
+
+// Change is the update a Transaction makes to a single version file.
+type Change struct {
+	File   string
+	Before string
+	After  string
+}
+
+// stagedWrite is a single version file update held by a Transaction.
+type stagedWrite struct {
+	inputFile string
+	path      string
+	tmpPath   string
+	original  []byte
+	updated   string
+	mode      fs.FileMode
+	applied   bool
+	// tracked is set for files changed outside the transaction, see Track.
+	tracked bool
+	// created is set for tracked files that didn't exist, so are removed
+	// rather than restored.
+	created bool
+}
+
+// Stage validates the version can be written to the file and stages the new
+// contents in a temp file next to it. Nothing is changed until Commit.
+func (t *Transaction) Stage(dir string, inputFile string, opts WriteOptions) error {
+	_, err := t.stage(dir, inputFile, func(contents string) (string, bool, error) {
+		newContents, err := updatedContents(inputFile, contents, opts)
+
+		return newContents, true, err
+	})
+
+	return err
+}
+
+// stage stages the contents of the file returned by update, or nothing when
+// update returns false because the file has nothing to change.
+func (t *Transaction) stage(
+	dir string,
+	inputFile string,
+	update func(contents string) (string, bool, error),
+) (bool, error) {
 	path := filepath.Clean(versionFilePath(dir, inputFile))
 
-	file, err := os.Open(path)
+	info, err := os.Stat(path)
+	if err != nil {
+		return false, fmt.Errorf("error opening file: %w", err)
+	}
+
+	original, err := os.ReadFile(path)
+	if err != nil {
+		return false, fmt.Errorf("error reading file %s: %w", inputFile, err)
+	}
+
+	newContents, changed, err := update(string(original))
+	if err != nil || !changed {
+		return false, err
+	}
+
+	tmpPath := ""
+	if !t.DryRun {
+		tmpPath, err = createTempFile(path, newContents, info.Mode())
+		if err != nil {
+			return false, err
+		}
+	}
+
+	t.writes = append(t.writes, stagedWrite{
+		inputFile: inputFile,
+		path:      path,
+		tmpPath:   tmpPath,
+		original:  original,
+		updated:   newContents,
+		mode:      info.Mode(),
+		applied:   false,
+	})
+
+	return true, nil
+}
+
+// Track records the current contents of a file that is about to be changed
+// outside of the transaction, e.g. by a hook command, so Rollback restores it
+// along with the version files. A file that doesn't exist yet is removed by
+// Rollback. Tracked files aren't included in Changes.
+func (t *Transaction) Track(dir string, inputFile string) error {
+	if t.DryRun {
+		return nil
+	}
+
+	path := filepath.Clean(versionFilePath(dir, inputFile))
+
+	info, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		t.writes = append(t.writes, stagedWrite{
+			inputFile: inputFile,
+			path:      path,
+			applied:   true,
+			tracked:   true,
+			created:   true,
+		})
+
+		return nil
+	}
+
 	if err != nil {
 		return fmt.Errorf("error opening file: %w", err)
 	}
 
-	info, statErr := file.Stat()
+	original, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading file %s: %w", inputFile, err)
+	}
 
-	matcher := getVersionMatcher(inputFile)
-	newContents, updateErr := matcher.updateVersionInPlace(newScanner(file), opts)
+	t.writes = append(t.writes, stagedWrite{
+		inputFile: inputFile,
+		path:      path,
+		original:  original,
+		mode:      info.Mode(),
+		applied:   true,
+		tracked:   true,
+	})
 
-	// The whole file has been read so close it before any error handling,
-	// it also has to be closed before the rename below works on Windows.
-	if err := file.Close(); err != nil {
-		return fmt.Errorf("error closing file %s: %w", inputFile, err)
+	return nil
+}
+
+// Changes returns the staged update to each file, in the order they were
+// staged.
+func (t *Transaction) Changes() []Change {
+	changes := make([]Change, 0, len(t.writes))
+
+	for _, write := range t.writes {
+		if write.tracked {
+			continue
+		}
+
+		changes = append(changes, Change{
+			File:   write.inputFile,
+			Before: string(write.original),
+			After:  write.updated,
+		})
 	}
 
-	if statErr != nil {
-		return fmt.Errorf("error reading file info for %s: %w", inputFile, statErr)
+	return changes
+}
+
+// Commit replaces every staged file with its new contents. If any replacement
+// fails the files already replaced are restored, so either every file is
+// updated or none are.
+func (t *Transaction) Commit() error {
+	if t.DryRun {
+		return nil
 	}
 
-	if updateErr != nil {
-		return updateErr
+	for i := range t.writes {
+		write := &t.writes[i]
+		if write.tracked {
+			continue
+		}
+
+		// #nosec G703 -- intentional: this CLI allows user-directed file paths.
+		if err := os.Rename(write.tmpPath, write.path); err != nil {
+			renameErr := fmt.Errorf("error renaming temp file for %s: %w", write.inputFile, err)
+
+			return errors.Join(renameErr, t.Rollback())
+		}
+
+		write.applied = true
 	}
 
-	// The temp file is created next to the version file so the rename below
-	// can't cross filesystems.
-	tmpFile, err := os.CreateTemp(filepath.Dir(path), "vrsn-tmp-*")
+	return nil
+}
+
+// Rollback restores the original contents of any files already replaced and
+// removes any staged temp files that weren't used. It is safe to call after a
+// successful Commit, e.g. when committing the files to git fails. Files are
+// restored in the reverse of the order they were added, so a version file
+// tracked again after it was written ends up with its contents from before
+// the transaction.
+func (t *Transaction) Rollback() error {
+	errs := []error{}
+
+	for _, write := range slices.Backward(t.writes) {
+		if !write.applied {
+			if write.tmpPath != "" {
+				// Best effort cleanup, the temp file is never read again.
+				_ = os.Remove(write.tmpPath)
+			}
+
+			continue
+		}
+
+		if write.created {
+			if err := os.Remove(write.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				errs = append(errs, fmt.Errorf("error removing %s: %w", write.inputFile, err))
+			}
+
+			continue
+		}
+
+		tmpPath, err := createTempFile(write.path, string(write.original), write.mode)
+		if err == nil {
+			// #nosec G703 -- intentional: this CLI allows user-directed file paths.
+			err = os.Rename(tmpPath, write.path)
+			if err != nil {
+				_ = os.Remove(tmpPath)
+			}
+		}
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error restoring %s: %w", write.inputFile, err))
+		}
+	}
+
+	t.writes = nil
+
+	return errors.Join(errs...)
+}
+
+// updatedContents returns the file contents with the new version written in,
+// keeping the original layout of the file.
+func updatedContents(inputFile string, contents string, opts WriteOptions) (string, error) {
+	normalised, layout := parseLayout(contents)
+
+	matcher := getVersionMatcher(inputFile, opts.Anchors)
+
+	newLines, err := matcher.updateVersionInPlace(
+		newScanner(strings.NewReader(normalised)),
+		opts,
+	)
 	if err != nil {
-		return fmt.Errorf("error creating temp file: %w", err)
+		return "", err
 	}
 
-	if err := writeTempFile(tmpFile, newContents, info.Mode()); err != nil {
-		// Best effort cleanup, the write error is more useful than any
-		// remove error.
-		_ = os.Remove(tmpFile.Name())
+	return layout.render(newLines), nil
+}
 
-		return err
+// createTempFile writes the contents to a new temp file next to the file at
+// path, so a rename over path can't cross filesystems, and returns its path.
+// The mode is applied so the file's permissions are preserved by the rename.
+func createTempFile(path string, contents string, mode fs.FileMode) (string, error) {
+	tmpFile, err := os.CreateTemp(filepath.Dir(path), "vrsn-tmp-*")
+	if err != nil {
+		return "", fmt.Errorf("error creating temp file: %w", err)
 	}
 
-	// #nosec G703 -- intentional: this CLI allows user-directed file paths.
-	if err := os.Rename(tmpFile.Name(), path); err != nil {
+	if err := writeTempFile(tmpFile, contents, mode); err != nil {
+		// Best effort cleanup, the write error is more useful than any
+		// remove error.
 		_ = os.Remove(tmpFile.Name())
 
-		return fmt.Errorf("error renaming temp file: %w", err)
+		return "", err
 	}
 
-	return nil
+	return tmpFile.Name(), nil
 }
 
-// writeTempFile writes the lines to the temp file and applies the original
+// writeTempFile writes the contents to the temp file and applies the original
 // version file's permissions so they are preserved by the rename.
-func writeTempFile(tmpFile *os.File, lines []string, mode fs.FileMode) error {
-	for _, line := range lines {
-		if _, err := tmpFile.WriteString(line + "\n"); err != nil {
-			_ = tmpFile.Close()
+func writeTempFile(tmpFile *os.File, contents string, mode fs.FileMode) error {
+	if _, err := tmpFile.WriteString(contents); err != nil {
+		_ = tmpFile.Close()
 
-			return fmt.Errorf("error writing string to file: %w", err)
-		}
+		return fmt.Errorf("error writing string to file: %w", err)
 	}
 
 	if err := tmpFile.Chmod(mode); err != nil {
@@ -3,6 +3,7 @@ package files_test
 import (
 	"os"
 	"path/filepath"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -85,6 +86,144 @@ func TestWriteVersionToFile(t *testing.T) {
 			newVersion:    "",
 			expectedError: files.ErrGettingVersionFromAndroidManifest,
 		},
+		"WritesVersionToInfoPlist": {
+			parentDir:     "all",
+			inputFile:     "Info.plist",
+			newVersion:    "3.2.0",
+			expectedError: nil,
+		},
+		"ReturnsErrorForInvalidInfoPlist": {
+			parentDir:     "no-version",
+			inputFile:     "Info.plist",
+			newVersion:    "",
+			expectedError: files.ErrGettingVersionFromInfoPlist,
+		},
+		"WritesVersionToXcodeProject": {
+			parentDir:     "all",
+			inputFile:     "project.pbxproj",
+			newVersion:    "1.6.0",
+			expectedError: nil,
+		},
+		"ReturnsErrorForInvalidXcodeProject": {
+			parentDir:     "no-version",
+			inputFile:     "project.pbxproj",
+			newVersion:    "",
+			expectedError: files.ErrGettingVersionFromXcodeProject,
+		},
+		"WritesVersionToGoSource": {
+			parentDir:     "all",
+			inputFile:     "version.go",
+			newVersion:    "4.2.99",
+			expectedError: nil,
+		},
+		"WritesVersionToGoSourceByExtension": {
+			parentDir:     "all",
+			inputFile:     "cmd/root.go",
+			newVersion:    "2.3.9",
+			expectedError: nil,
+		},
+		"WritesVersionToPythonSourceByExtension": {
+			parentDir:     "all",
+			inputFile:     "pkg/meta.py",
+			newVersion:    "0.8.9",
+			expectedError: nil,
+		},
+		"WritesVersionToRubySourceByExtension": {
+			parentDir:     "all",
+			inputFile:     "lib/app/info.rb",
+			newVersion:    "3.0.9",
+			expectedError: nil,
+		},
+		"WritesVersionToElixirSourceByExtension": {
+			parentDir:     "all",
+			inputFile:     "config.exs",
+			newVersion:    "1.1.9",
+			expectedError: nil,
+		},
+		"ReturnsErrorForInvalidGoSource": {
+			parentDir:     "no-version",
+			inputFile:     "version.go",
+			newVersion:    "",
+			expectedError: files.ErrGettingVersionFromGoSource,
+		},
+		"WritesPrefixedVersionToGoSource": {
+			parentDir:     "prefixed",
+			inputFile:     "version.go",
+			newVersion:    "v4.2.99",
+			expectedError: nil,
+		},
+		"WritesVersionToPythonSource": {
+			parentDir:     "all",
+			inputFile:     "__init__.py",
+			newVersion:    "0.7.99",
+			expectedError: nil,
+		},
+		"ReturnsErrorForInvalidPythonSource": {
+			parentDir:     "no-version",
+			inputFile:     "__init__.py",
+			newVersion:    "",
+			expectedError: files.ErrGettingVersionFromPythonSource,
+		},
+		"WritesPrefixedVersionToPythonSource": {
+			parentDir:     "prefixed",
+			inputFile:     "__init__.py",
+			newVersion:    "v0.7.99",
+			expectedError: nil,
+		},
+		"WritesVersionToRubySource": {
+			parentDir:     "all",
+			inputFile:     "version.rb",
+			newVersion:    "1.9.99",
+			expectedError: nil,
+		},
+		"ReturnsErrorForInvalidRubySource": {
+			parentDir:     "no-version",
+			inputFile:     "version.rb",
+			newVersion:    "",
+			expectedError: files.ErrGettingVersionFromRubySource,
+		},
+		"WritesPrefixedVersionToRubySource": {
+			parentDir:     "prefixed",
+			inputFile:     "version.rb",
+			newVersion:    "v1.9.99",
+			expectedError: nil,
+		},
+		"WritesVersionToGemspec": {
+			parentDir:     "all",
+			inputFile:     "vrsn.gemspec",
+			newVersion:    "1.9.99",
+			expectedError: nil,
+		},
+		"ReturnsErrorForInvalidGemspec": {
+			parentDir:     "no-version",
+			inputFile:     "vrsn.gemspec",
+			newVersion:    "",
+			expectedError: files.ErrGettingVersionFromRubySource,
+		},
+		"WritesPrefixedVersionToGemspec": {
+			parentDir:     "prefixed",
+			inputFile:     "vrsn.gemspec",
+			newVersion:    "v1.9.99",
+			expectedError: nil,
+		},
+		"WritesVersionToMixExs": {
+			parentDir:     "all",
+			inputFile:     "mix.exs",
+			newVersion:    "0.12.99",
+			expectedError: nil,
+		},
+		"ReturnsErrorForInvalidMixExs": {
+			parentDir:     "no-version",
+			inputFile:     "mix.exs",
+			newVersion:    "",
+			expectedError: files.ErrGettingVersionFromMixExs,
+		},
+		"WritesPrefixedVersionToMixExs": {
+			parentDir:     "prefixed",
+			inputFile:     "mix.exs",
+			newVersion:    "v0.12.99",
+			expectedError: nil,
+		},
 		"WritesVersionToBuildGradle": {
 			parentDir:     "all",
 			inputFile:     "build.gradle",
@@ -115,6 +254,12 @@ func TestWriteVersionToFile(t *testing.T) {
 			newVersion:    "2.14.741",
 			expectedError: nil,
 		},
+		"WritesVersionToPubspecYAML": {
+			parentDir:     "all",
+			inputFile:     "pubspec.yaml",
+			newVersion:    "1.5.0",
+			expectedError: nil,
+		},
 		"ReturnsErrorForInvalidCargoTOML": {
 			parentDir:     "no-version",
 			inputFile:     "Cargo.toml",
@@ -235,7 +380,7 @@ func TestWriteVersionToFile(t *testing.T) {
 				return
 			}
 
-			actual, err := files.GetVersionFromFile(tmpDir, tc.inputFile)
+			actual, err := files.GetVersionFromFile(tmpDir, tc.inputFile, files.ReadOptions{})
 			require.NoError(t, err)
 
 			assert.Equal(t, tc.newVersion, actual)
@@ -325,7 +470,7 @@ func TestWriteVersionToFileAndroidVersionCode(t *testing.T) {
 	}{
 		"BumpsVersionCodeWhenSupplied": {
 			content: withCode,
-			opts:    files.WriteOptions{NewVersion: "1.3.0", AndroidVersionCode: "10300"},
+			opts:    files.WriteOptions{NewVersion: "1.3.0", AndroidVersionCode: files.FixedBuildNumber("10300")},
 			expectedContents: `<manifest
     android:versionCode="10300"
     android:versionName="1.3.0">
@@ -343,7 +488,7 @@ func TestWriteVersionToFileAndroidVersionCode(t *testing.T) {
 		},
 		"ErrorsWhenVersionCodeMissing": {
 			content:       withoutCode,
-			opts:          files.WriteOptions{NewVersion: "1.3.0", AndroidVersionCode: "10300"},
+			opts:          files.WriteOptions{NewVersion: "1.3.0", AndroidVersionCode: files.FixedBuildNumber("10300")},
 			expectedError: files.ErrGettingVersionCodeFromAndroidManifest,
 			// the file is left unchanged when the bump errors.
 			expectedContents: withoutCode,
@@ -371,6 +516,463 @@ func TestWriteVersionToFileAndroidVersionCode(t *testing.T) {
 	}
 }
 
+// TestWriteVersionToFilePubspecBuildNumber checks the +N build number is
+// passed to the build number to derive the new one from, and kept when no
+// build number is supplied.
+func TestWriteVersionToFilePubspecBuildNumber(t *testing.T) {
+	t.Parallel()
+
+	increment := func(current string) (string, error) {
+		number, err := strconv.Atoi(current)
+
+		return strconv.Itoa(number + 1), err
+	}
+
+	testCases := map[string]struct {
+		content          string
+		opts             files.WriteOptions
+		expectedError    error
+		expectedContents string
+	}{
+		"DerivesBuildNumberFromCurrentOne": {
+			content:          "name: app\nversion: 1.2.3+41\n",
+			opts:             files.WriteOptions{NewVersion: "1.3.0", PubspecBuildNumber: increment},
+			expectedError:    nil,
+			expectedContents: "name: app\nversion: 1.3.0+42\n",
+		},
+		"KeepsBuildNumberWhenNotSupplied": {
+			content:          "name: app\nversion: 1.2.3+41\n",
+			opts:             files.WriteOptions{NewVersion: "1.3.0"},
+			expectedError:    nil,
+			expectedContents: "name: app\nversion: 1.3.0+41\n",
+		},
+		"KeepsPreReleaseSuffix": {
+			content:          "version: '1.2.3-beta+41'\n",
+			opts:             files.WriteOptions{NewVersion: "1.3.0", PubspecBuildNumber: files.FixedBuildNumber("10300")},
+			expectedError:    nil,
+			expectedContents: "version: '1.3.0-beta+10300'\n",
+		},
+		"ErrorsWhenBuildNumberMissing": {
+			content:          "version: 1.2.3\n",
+			opts:             files.WriteOptions{NewVersion: "1.3.0", PubspecBuildNumber: increment},
+			expectedError:    files.ErrGettingBuildNumberFromPubspec,
+			expectedContents: "version: 1.2.3\n",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			file := "pubspec.yaml"
+			require.NoError(
+				t,
+				os.WriteFile(filepath.Clean(filepath.Join(dir, file)), []byte(tc.content), 0o600),
+			)
+
+			err := files.WriteVersionToFile(dir, file, tc.opts)
+			require.ErrorIs(t, err, tc.expectedError)
+
+			actual, readErr := os.ReadFile(filepath.Clean(filepath.Join(dir, file)))
+			require.NoError(t, readErr)
+			assert.Equal(t, tc.expectedContents, string(actual))
+		})
+	}
+}
+
+// TestWriteVersionToFileAppleBuildNumber checks every MARKETING_VERSION and
+// CFBundleShortVersionString occurrence is updated, the build number is only
+// bumped when supplied, and build setting references are never overwritten.
+func TestWriteVersionToFileAppleBuildNumber(t *testing.T) {
+	t.Parallel()
+
+	const xcodeProject = `buildSettings = {
+	CURRENT_PROJECT_VERSION = 10203;
+	MARKETING_VERSION = 1.2.3;
+};
+buildSettings = {
+	CURRENT_PROJECT_VERSION = 10203;
+	MARKETING_VERSION = 1.2.3;
+};
+`
+
+	const infoPlist = `<dict>
+	<key>CFBundleShortVersionString</key>
+	<string>1.2.3</string>
+	<key>CFBundleVersion</key>
+	<string>10203</string>
+</dict>
+`
+
+	const infoPlistWithReference = `<dict>
+	<key>CFBundleShortVersionString</key>
+	<string>1.2.3</string>
+	<key>CFBundleVersion</key>
+	<string>$(CURRENT_PROJECT_VERSION)</string>
+</dict>
+`
+
+	testCases := map[string]struct {
+		file             string
+		content          string
+		opts             files.WriteOptions
+		expectedError    error
+		expectedContents string
+	}{
+		"UpdatesEveryBuildConfigurationInXcodeProject": {
+			file:    "project.pbxproj",
+			content: xcodeProject,
+			opts:    files.WriteOptions{NewVersion: "1.3.0", AppleBuildNumber: files.FixedBuildNumber("10300")},
+			expectedContents: `buildSettings = {
+	CURRENT_PROJECT_VERSION = 10300;
+	MARKETING_VERSION = 1.3.0;
+};
+buildSettings = {
+	CURRENT_PROJECT_VERSION = 10300;
+	MARKETING_VERSION = 1.3.0;
+};
+`,
+		},
+		"LeavesXcodeBuildNumberWhenNotSupplied": {
+			file:    "project.pbxproj",
+			content: xcodeProject,
+			opts:    files.WriteOptions{NewVersion: "1.3.0"},
+			expectedContents: `buildSettings = {
+	CURRENT_PROJECT_VERSION = 10203;
+	MARKETING_VERSION = 1.3.0;
+};
+buildSettings = {
+	CURRENT_PROJECT_VERSION = 10203;
+	MARKETING_VERSION = 1.3.0;
+};
+`,
+		},
+		"BumpsInfoPlistBuildNumberWhenSupplied": {
+			file:    "Info.plist",
+			content: infoPlist,
+			opts:    files.WriteOptions{NewVersion: "1.3.0", AppleBuildNumber: files.FixedBuildNumber("10300")},
+			expectedContents: `<dict>
+	<key>CFBundleShortVersionString</key>
+	<string>1.3.0</string>
+	<key>CFBundleVersion</key>
+	<string>10300</string>
+</dict>
+`,
+		},
+		"ErrorsWhenInfoPlistBuildNumberIsAReference": {
+			file:          "Info.plist",
+			content:       infoPlistWithReference,
+			opts:          files.WriteOptions{NewVersion: "1.3.0", AppleBuildNumber: files.FixedBuildNumber("10300")},
+			expectedError: files.ErrGettingBuildNumberFromInfoPlist,
+			// the file is left unchanged when the bump errors.
+			expectedContents: infoPlistWithReference,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			require.NoError(
+				t,
+				os.WriteFile(filepath.Clean(filepath.Join(dir, tc.file)), []byte(tc.content), 0o600),
+			)
+
+			err := files.WriteVersionToFile(dir, tc.file, tc.opts)
+			require.ErrorIs(t, err, tc.expectedError)
+
+			actual, readErr := os.ReadFile(filepath.Clean(filepath.Join(dir, tc.file)))
+			require.NoError(t, readErr)
+			assert.Equal(t, tc.expectedContents, string(actual))
+		})
+	}
+}
+
+// TestWriteVersionToFileWithAnchors checks every anchored occurrence of the
+// version is updated and unrelated versions in the file are left alone.
+func TestWriteVersionToFileWithAnchors(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	file := "Dockerfile"
+	content := `FROM golang:1.26.1 AS build
+ARG VERSION=1.2.3
+LABEL org.opencontainers.image.version="1.2.3"
+`
+	require.NoError(
+		t,
+		os.WriteFile(filepath.Clean(filepath.Join(dir, file)), []byte(content), 0o600),
+	)
+
+	err := files.WriteVersionToFile(dir, file, files.WriteOptions{
+		NewVersion: "1.3.0",
+		Anchors:    []string{"ARG VERSION=", "image.version="},
+	})
+	require.NoError(t, err)
+
+	actual, err := os.ReadFile(filepath.Clean(filepath.Join(dir, file)))
+	require.NoError(t, err)
+	assert.Equal(t, `FROM golang:1.26.1 AS build
+ARG VERSION=1.3.0
+LABEL org.opencontainers.image.version="1.3.0"
+`, string(actual))
+}
+
+// TestWriteVersionToFilePreservesLayout checks line endings, byte order marks
+// and the trailing newline state survive a write so only the version changes.
+func TestWriteVersionToFilePreservesLayout(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		file             string
+		content          string
+		expectedContents string
+	}{
+		"PreservesCRLFLineEndings": {
+			file:             "AndroidManifest.xml",
+			content:          "<manifest\r\n    android:versionName=\"1.2.3\">\r\n</manifest>\r\n",
+			expectedContents: "<manifest\r\n    android:versionName=\"1.3.0\">\r\n</manifest>\r\n",
+		},
+		"PreservesMixedLineEndings": {
+			file:             "package.json",
+			content:          "{\r\n  \"version\": \"1.2.3\"\n}\r\n",
+			expectedContents: "{\r\n  \"version\": \"1.3.0\"\n}\r\n",
+		},
+		"PreservesMissingTrailingNewline": {
+			file:             "package.json",
+			content:          "{\n  \"version\": \"1.2.3\"\n}",
+			expectedContents: "{\n  \"version\": \"1.3.0\"\n}",
+		},
+		"PreservesMissingTrailingNewlineInVERSIONFile": {
+			file:             "VERSION",
+			content:          "1.2.3",
+			expectedContents: "1.3.0",
+		},
+		"PreservesTrailingBlankLines": {
+			file:             "package.json",
+			content:          "{\n  \"version\": \"1.2.3\"\n}\n\n",
+			expectedContents: "{\n  \"version\": \"1.3.0\"\n}\n\n",
+		},
+		"PreservesTrailingBlankCRLFLines": {
+			file:             "AndroidManifest.xml",
+			content:          "<manifest\r\n    android:versionName=\"1.2.3\">\r\n</manifest>\r\n\r\n\r\n",
+			expectedContents: "<manifest\r\n    android:versionName=\"1.3.0\">\r\n</manifest>\r\n\r\n\r\n",
+		},
+		"PreservesTrailingBlankLineInVERSIONFile": {
+			file:             "VERSION",
+			content:          "1.2.3\n\n",
+			expectedContents: "1.3.0\n\n",
+		},
+		"PreservesByteOrderMark": {
+			file:             "VERSION",
+			content:          "\ufeff1.2.3\r\n",
+			expectedContents: "\ufeff1.3.0\r\n",
+		},
+		"UsesFileLineEndingForEmptyVERSIONFile": {
+			file:             "VERSION",
+			content:          "",
+			expectedContents: "1.3.0\n",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			require.NoError(
+				t,
+				os.WriteFile(filepath.Clean(filepath.Join(dir, tc.file)), []byte(tc.content), 0o600),
+			)
+
+			err := files.WriteVersionToFile(dir, tc.file, files.WriteOptions{NewVersion: "1.3.0"})
+			require.NoError(t, err)
+
+			actual, err := os.ReadFile(filepath.Clean(filepath.Join(dir, tc.file)))
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedContents, string(actual))
+
+			version, err := files.GetVersionFromFile(dir, tc.file, files.ReadOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, "1.3.0", version)
+		})
+	}
+}
+
+// TestTransaction checks a multi-file write is all-or-nothing: a file that
+// can't be updated stops any file being written, and a rollback after a
+// commit restores every original.
+func TestTransaction(t *testing.T) {
+	t.Parallel()
+
+	const packageJSON = "{\n  \"version\": \"1.2.3\"\n}\n"
+
+	testCases := map[string]struct {
+		files            map[string]string
+		rollbackAfter    bool
+		expectedError    error
+		expectedContents map[string]string
+	}{
+		"WritesEveryFileOnCommit": {
+			files:         map[string]string{"VERSION": "1.2.3\n", "package.json": packageJSON},
+			rollbackAfter: false,
+			expectedError: nil,
+			expectedContents: map[string]string{
+				"VERSION":      "1.3.0\n",
+				"package.json": "{\n  \"version\": \"1.3.0\"\n}\n",
+			},
+		},
+		"WritesNothingWhenAFileCannotBeStaged": {
+			files:         map[string]string{"VERSION": "1.2.3\n", "package.json": "{}\n"},
+			rollbackAfter: false,
+			expectedError: files.ErrGettingVersionFromPackageJSON,
+			expectedContents: map[string]string{
+				"VERSION":      "1.2.3\n",
+				"package.json": "{}\n",
+			},
+		},
+		"RestoresEveryFileOnRollbackAfterCommit": {
+			files:         map[string]string{"VERSION": "1.2.3\n", "package.json": packageJSON},
+			rollbackAfter: true,
+			expectedError: nil,
+			expectedContents: map[string]string{
+				"VERSION":      "1.2.3\n",
+				"package.json": packageJSON,
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			for file, content := range tc.files {
+				require.NoError(
+					t,
+					os.WriteFile(filepath.Clean(filepath.Join(dir, file)), []byte(content), 0o600),
+				)
+			}
+
+			var txn files.Transaction
+
+			err := txn.Stage(dir, "VERSION", files.WriteOptions{NewVersion: "1.3.0"})
+			require.NoError(t, err)
+
+			err = txn.Stage(dir, "package.json", files.WriteOptions{NewVersion: "1.3.0"})
+			require.ErrorIs(t, err, tc.expectedError)
+
+			if err != nil {
+				require.NoError(t, txn.Rollback())
+			} else {
+				require.NoError(t, txn.Commit())
+			}
+
+			if tc.rollbackAfter {
+				require.NoError(t, txn.Rollback())
+			}
+
+			for file, expected := range tc.expectedContents {
+				actual, err := os.ReadFile(filepath.Clean(filepath.Join(dir, file)))
+				require.NoError(t, err)
+				assert.Equal(t, expected, string(actual), file)
+			}
+
+			// No staged temp files are left behind.
+			entries, err := os.ReadDir(dir)
+			require.NoError(t, err)
+			assert.Len(t, entries, len(tc.files))
+		})
+	}
+}
+
+// TestTransactionDryRun checks a dry run reports the changes without touching
+// the files on disk.
+func TestTransactionDryRun(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "VERSION")
+	require.NoError(t, os.WriteFile(path, []byte("1.2.3\n"), 0o600))
+
+	txn := files.Transaction{DryRun: true}
+
+	require.NoError(t, txn.Stage(dir, "VERSION", files.WriteOptions{NewVersion: "1.3.0"}))
+	require.NoError(t, txn.Commit())
+
+	assert.Equal(
+		t,
+		[]files.Change{{File: "VERSION", Before: "1.2.3\n", After: "1.3.0\n"}},
+		txn.Changes(),
+	)
+
+	actual, err := os.ReadFile(filepath.Clean(path))
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.3\n", string(actual))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+// TestTransactionTrack checks files changed outside the transaction are
+// restored, or removed if they were created, on rollback.
+func TestTransactionTrack(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "VERSION"), []byte("1.2.3\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "lock.txt"), []byte("1.2.3\n"), 0o600))
+
+	var txn files.Transaction
+
+	require.NoError(t, txn.Stage(dir, "VERSION", files.WriteOptions{NewVersion: "1.3.0"}))
+	require.NoError(t, txn.Commit())
+	require.NoError(t, txn.Track(dir, "lock.txt"))
+	require.NoError(t, txn.Track(dir, "new.txt"))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "lock.txt"), []byte("1.3.0\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "new.txt"), []byte("1.3.0\n"), 0o600))
+
+	assert.Equal(
+		t,
+		[]files.Change{{File: "VERSION", Before: "1.2.3\n", After: "1.3.0\n"}},
+		txn.Changes(),
+	)
+
+	require.NoError(t, txn.Rollback())
+
+	for _, file := range []string{"VERSION", "lock.txt"} {
+		actual, err := os.ReadFile(filepath.Clean(filepath.Join(dir, file)))
+		require.NoError(t, err)
+		assert.Equal(t, "1.2.3\n", string(actual), file)
+	}
+
+	assert.NoFileExists(t, filepath.Join(dir, "new.txt"))
+}
+
+func TestTransactionTrackStagedFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "package.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"version": "1.2.3"}`), 0o600))
+
+	var txn files.Transaction
+
+	require.NoError(t, txn.Stage(dir, "package.json", files.WriteOptions{NewVersion: "1.3.0"}))
+	require.NoError(t, txn.Commit())
+	// A hook's commit-files can list a file that was already written.
+	require.NoError(t, txn.Track(dir, "package.json"))
+	require.NoError(t, txn.Rollback())
+
+	actual, err := os.ReadFile(filepath.Clean(path))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"version": "1.2.3"}`, string(actual))
+}
+
 // TestWriteVersionToFilePreservesPermissions checks the original file mode
 // survives the temp file replacing the version file.
 func TestWriteVersionToFilePreservesPermissions(t *testing.T) {
@@ -404,7 +1006,7 @@ func TestWriteVersionToFileWithAbsolutePath(t *testing.T) {
 	)
 	require.NoError(t, err)
 
-	actual, err := files.GetVersionFromFile("/another/dir", absPath)
+	actual, err := files.GetVersionFromFile("/another/dir", absPath, files.ReadOptions{})
 	require.NoError(t, err)
 	assert.Equal(t, "4.5.6", actual)
 }
@@ -419,6 +1021,8 @@ func copyTestFile(t *testing.T, parentDir, filename string) string {
 	require.NoError(t, err)
 
 	testPath := filepath.Join(tmpDir, filename)
+	require.NoError(t, os.MkdirAll(filepath.Dir(testPath), 0o750))
+
 	//#nosec: G703
 	err = os.WriteFile(testPath, data, 0o600)
 	require.NoError(t, err)
@@ -2,6 +2,7 @@ package files
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/tx3stn/vrsn/internal/logger"
 )
@@ -9,36 +10,87 @@ import (
 // GetVersionsFromFiles reads the version from each of the provided files and
 // returns the common version they all contain.
 // The version found in each file is debug logged, and if the versions do not
-// all match an ErrVersionsDoNotMatch error is returned.
-func GetVersionsFromFiles(dir string, versionFiles []string, log logger.Basic) (string, error) {
+// all match an ErrVersionsDoNotMatch error naming the outliers is returned.
+// anchors holds the configured anchors for any of the files, keyed by the
+// file as it appears in versionFiles.
+func GetVersionsFromFiles(
+	dir string,
+	versionFiles []string,
+	anchors map[string][]string,
+	log logger.Logger,
+) (string, error) {
 	if len(versionFiles) == 0 {
 		return "", ErrNoVersionFilesInDir
 	}
 
-	versions := make([]string, 0, len(versionFiles))
+	versions := make([]SourceVersion, 0, len(versionFiles))
 
 	for _, file := range versionFiles {
-		version, err := GetVersionFromFile(dir, file)
+		version, err := GetVersionFromFile(dir, file, ReadOptions{Anchors: anchors[file]})
 		if err != nil {
 			return "", fmt.Errorf("error getting version from file %s: %w", file, err)
 		}
 
 		log.Debugf("file %s has version %s", file, version)
 
-		versions = append(versions, version)
+		versions = append(versions, SourceVersion{Source: file, Version: version})
 	}
 
 	return CommonVersion(versions)
 }
 
-// CommonVersion returns the version shared by all of the provided versions,
-// or an ErrVersionsDoNotMatch error when they aren't all the same.
-func CommonVersion(versions []string) (string, error) {
-	for _, version := range versions {
-		if version != versions[0] {
-			return "", ErrVersionsDoNotMatch
+// SourceVersion is the version found in a single source, e.g. a version file
+// or the latest git tag.
+type SourceVersion struct {
+	Source  string
+	Version string
+}
+
+// CommonVersion returns the version shared by all of the sources, or an
+// ErrVersionsDoNotMatch error naming the sources that differ from the version
+// most of them have when they aren't all the same.
+func CommonVersion(sources []SourceVersion) (string, error) {
+	expected, outliers := Outliers(sources)
+	if len(outliers) == 0 {
+		return expected, nil
+	}
+
+	details := make([]string, 0, len(outliers))
+	for _, outlier := range outliers {
+		details = append(details, outlier.Source+" has "+outlier.Version)
+	}
+
+	return "", fmt.Errorf(
+		"%w: expected %s but %s", ErrVersionsDoNotMatch, expected, strings.Join(details, ", "),
+	)
+}
+
+// Outliers returns the version most of the sources have and the sources with
+// any other version. A tie goes to the version of the first of the sources,
+// so the files listed first win.
+func Outliers(sources []SourceVersion) (string, []SourceVersion) {
+	if len(sources) == 0 {
+		return "", []SourceVersion{}
+	}
+
+	counts := map[string]int{}
+	expected := sources[0].Version
+
+	for _, source := range sources {
+		counts[source.Version]++
+
+		if counts[source.Version] > counts[expected] {
+			expected = source.Version
+		}
+	}
+
+	outliers := []SourceVersion{}
+
+	for _, source := range sources {
+		if source.Version != expected {
+			outliers = append(outliers, source)
 		}
 	}
 
-	return versions[0], nil
+	return expected, outliers
 }
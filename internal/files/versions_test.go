@@ -2,6 +2,7 @@ package files_test
 
 import (
 	"io/fs"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"testing"
@@ -76,11 +77,78 @@ func TestGetVersionsFromFiles(t *testing.T) {
 				require.NoError(t, err)
 			}
 
-			log := logger.NewBasic(false, false)
+			log := logger.NewBasic(false, slog.New(slog.DiscardHandler))
 
-			version, err := files.GetVersionsFromFiles(dir, tc.versionFiles, log)
+			version, err := files.GetVersionsFromFiles(dir, tc.versionFiles, nil, log)
 			require.ErrorIs(t, err, tc.expectedError)
 			assert.Equal(t, tc.expected, version)
 		})
 	}
 }
+
+func TestCommonVersion(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		sources          []files.SourceVersion
+		expected         string
+		expectedOutliers []files.SourceVersion
+		expectedError    string
+	}{
+		"ReturnsVersionWhenAllSourcesMatch": {
+			sources: []files.SourceVersion{
+				{Source: "VERSION", Version: "1.2.3"},
+				{Source: "package.json", Version: "1.2.3"},
+			},
+			expected:         "1.2.3",
+			expectedOutliers: []files.SourceVersion{},
+			expectedError:    "",
+		},
+		"NamesTheSourcesThatDifferFromTheMajority": {
+			sources: []files.SourceVersion{
+				{Source: "VERSION", Version: "1.2.3"},
+				{Source: "package.json", Version: "1.3.0"},
+				{Source: "chart/Chart.yaml", Version: "1.2.3"},
+				{Source: "git tag", Version: "1.2.0"},
+			},
+			expected: "1.2.3",
+			expectedOutliers: []files.SourceVersion{
+				{Source: "package.json", Version: "1.3.0"},
+				{Source: "git tag", Version: "1.2.0"},
+			},
+			expectedError: "version files do not contain matching versions: " +
+				"expected 1.2.3 but package.json has 1.3.0, git tag has 1.2.0",
+		},
+		"TieGoesToTheFirstSource": {
+			sources: []files.SourceVersion{
+				{Source: "VERSION", Version: "1.2.3"},
+				{Source: "package.json", Version: "1.3.0"},
+			},
+			expected:         "1.2.3",
+			expectedOutliers: []files.SourceVersion{{Source: "package.json", Version: "1.3.0"}},
+			expectedError: "version files do not contain matching versions: " +
+				"expected 1.2.3 but package.json has 1.3.0",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			expected, outliers := files.Outliers(tc.sources)
+			assert.Equal(t, tc.expected, expected)
+			assert.Equal(t, tc.expectedOutliers, outliers)
+
+			common, err := files.CommonVersion(tc.sources)
+			if tc.expectedError == "" {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expected, common)
+
+				return
+			}
+
+			require.ErrorIs(t, err, files.ErrVersionsDoNotMatch)
+			require.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
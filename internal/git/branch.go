@@ -2,13 +2,15 @@
 package git
 
 import (
+	"context"
 	"fmt"
 )
 
 // CurrentBranch gets the name of the current branch.
-func CurrentBranch(dir string) (string, error) {
+func CurrentBranch(ctx context.Context, dir string) (string, error) {
 	// e.g.: git rev-parse --abrev-ref HEAD
 	return gitCommand(
+		ctx,
 		dir,
 		"error trying to get current git branch name",
 		"rev-parse", "--abbrev-ref", "HEAD",
@@ -16,11 +18,29 @@ func CurrentBranch(dir string) (string, error) {
 }
 
 // VersionAtBranch returns the version file contents from the specific branch.
-func VersionAtBranch(dir string, branchName string, versionFile string) (string, error) {
+func VersionAtBranch(
+	ctx context.Context,
+	dir string,
+	branchName string,
+	versionFile string,
+) (string, error) {
 	// e.g.: git --no-pager show main:VERSION
 	return gitCommand(
+		ctx,
 		dir,
 		fmt.Sprintf("error trying to read %s from %s", versionFile, branchName),
 		"--no-pager", "show", fmt.Sprintf("%s:%s", branchName, versionFile),
 	)
 }
+
+// PathPrefix returns the path of the directory relative to the root of the
+// repository with a trailing slash, or an empty string at the root.
+func PathPrefix(ctx context.Context, dir string) (string, error) {
+	// e.g.: git rev-parse --show-prefix
+	return gitCommand(
+		ctx,
+		dir,
+		"error trying to get path within git repository",
+		"rev-parse", "--show-prefix",
+	)
+}
@@ -2,14 +2,23 @@ package git
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 	"os/exec"
 	"strings"
+	"time"
+
+	"github.com/tx3stn/vrsn/internal/logger"
 )
 
-func gitCommand(dir string, errMsg string, args ...string) (string, error) {
+// gitCommand runs git with the args in dir, returning the trimmed stdout.
+// Every command run is debug logged with its args, duration and exit status,
+// so failures in CI can be diagnosed from the logs without rerunning.
+func gitCommand(ctx context.Context, dir string, errMsg string, args ...string) (string, error) {
 	// #nosec G204 -- args are intentional git CLI flags/subcommands
-	cmd := exec.Command("git", args...)
+	cmd := exec.CommandContext(ctx, "git", args...)
 	cmd.Dir = dir
 
 	var stdOut bytes.Buffer
@@ -19,9 +28,41 @@ func gitCommand(dir string, errMsg string, args ...string) (string, error) {
 	cmd.Stdout = &stdOut
 	cmd.Stderr = &stdErr
 
-	if err := cmd.Run(); err != nil {
-		return "", fmt.Errorf("%s: %w: %s", errMsg, err, stdErr.String())
+	start := time.Now()
+	err := cmd.Run()
+
+	logCommand(ctx, cmd, args, time.Since(start), stdErr.String())
+
+	if err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return "", fmt.Errorf("%s: %w", errMsg, ErrGitNotInstalled)
+		}
+
+		return "", fmt.Errorf("%s: %w: %w: %s", errMsg, ErrCommandFailed, err, stdErr.String())
 	}
 
 	return strings.Trim(stdOut.String(), "\n"), nil
 }
+
+// logCommand debug logs the git command that was run. The exit status is -1
+// when git couldn't be started, e.g. because it isn't installed.
+func logCommand(
+	ctx context.Context,
+	cmd *exec.Cmd,
+	args []string,
+	duration time.Duration,
+	stdErr string,
+) {
+	attrs := []any{
+		slog.String("args", strings.Join(args, " ")),
+		slog.String("dir", cmd.Dir),
+		slog.Duration("duration", duration),
+		slog.Int("exit_status", cmd.ProcessState.ExitCode()),
+	}
+
+	if stdErr != "" {
+		attrs = append(attrs, slog.String("stderr", strings.TrimSpace(stdErr)))
+	}
+
+	logger.FromContext(ctx).Debug("git command", attrs...)
+}
@@ -1,14 +1,17 @@
 package git
 
 import (
+	"context"
 	"fmt"
+	"strconv"
 	"strings"
 )
 
 // Add adds the version files to the git staging area.
-func Add(dir string, files ...string) (string, error) {
+func Add(ctx context.Context, dir string, files ...string) (string, error) {
 	// e.g.: git add package.json
 	return gitCommand(
+		ctx,
 		dir,
 		fmt.Sprintf("error staging %s, files will not be committed", strings.Join(files, ", ")),
 		append([]string{"add"}, files...)...,
@@ -16,14 +19,118 @@ func Add(dir string, files ...string) (string, error) {
 }
 
 // Commit commits just the version files with the provided commit message.
-func Commit(dir string, msg string, files ...string) (string, error) {
+func Commit(ctx context.Context, dir string, msg string, files ...string) (string, error) {
 	// e.g.: git commit package.json -m "bump version"
 	args := append([]string{"commit"}, files...)
 	args = append(args, "-m", msg)
 
 	return gitCommand(
+		ctx,
 		dir,
 		"error committing "+strings.Join(files, ", "),
 		args...,
 	)
 }
+
+// Unstage removes the files from the git staging area, resetting them to
+// their state at HEAD, without touching the working tree.
+func Unstage(ctx context.Context, dir string, files ...string) (string, error) {
+	// e.g.: git reset --quiet -- package.json
+	return gitCommand(
+		ctx,
+		dir,
+		"error unstaging "+strings.Join(files, ", "),
+		append([]string{"reset", "--quiet", "--"}, files...)...,
+	)
+}
+
+// TrackedFiles returns the files that are tracked by git, leaving out any that
+// are untracked or ignored.
+func TrackedFiles(ctx context.Context, dir string, files ...string) ([]string, error) {
+	// e.g.: git ls-files -- package-lock.json
+	tracked, err := gitCommand(
+		ctx,
+		dir,
+		"error checking if "+strings.Join(files, ", ")+" are tracked",
+		append([]string{"ls-files", "--"}, files...)...,
+	)
+	if err != nil || tracked == "" {
+		return []string{}, err
+	}
+
+	return strings.Split(tracked, "\n"), nil
+}
+
+// HeadCommit returns the SHA of the commit at HEAD.
+func HeadCommit(ctx context.Context, dir string) (string, error) {
+	// e.g.: git rev-parse HEAD
+	return gitCommand(
+		ctx,
+		dir,
+		"error getting HEAD commit",
+		"rev-parse", "HEAD",
+	)
+}
+
+// CommitCount returns the number of commits in the history of HEAD.
+func CommitCount(ctx context.Context, dir string) (int, error) {
+	// e.g.: git rev-list --count HEAD
+	count, err := gitCommand(
+		ctx,
+		dir,
+		"error counting commits",
+		"rev-list", "--count", "HEAD",
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	parsed, err := strconv.Atoi(count)
+	if err != nil {
+		return 0, fmt.Errorf("error counting commits: %w: %w", ErrCommandFailed, err)
+	}
+
+	return parsed, nil
+}
+
+// LastCommitTouching returns the SHA of the last commit that changed any of
+// the files, or an empty string if none of them have been committed.
+func LastCommitTouching(ctx context.Context, dir string, files ...string) (string, error) {
+	// e.g.: git log -1 --format=%H -- VERSION
+	return gitCommand(
+		ctx,
+		dir,
+		"error finding last commit to "+strings.Join(files, ", "),
+		append([]string{"--no-pager", "log", "-1", "--format=%H", "--"}, files...)...,
+	)
+}
+
+// CommitMessagesSince returns the full message of each commit after the ref
+// up to HEAD, or of every commit when the ref is empty.
+func CommitMessagesSince(ctx context.Context, dir string, ref string) ([]string, error) {
+	revisions := "HEAD"
+	if ref != "" {
+		revisions = ref + "..HEAD"
+	}
+
+	// e.g.: git log --format=%B%x00 0.1.0..HEAD
+	all, err := gitCommand(
+		ctx,
+		dir,
+		"error getting commit messages",
+		"--no-pager", "log", "--format=%B%x00", revisions,
+	)
+	if err != nil {
+		return []string{}, err
+	}
+
+	messages := []string{}
+
+	for message := range strings.SplitSeq(all, "\x00") {
+		if message = strings.TrimSpace(message); message != "" {
+			messages = append(messages, message)
+		}
+	}
+
+	return messages, nil
+}
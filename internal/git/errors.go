@@ -1,5 +1,7 @@
 package git
 
+import "strconv"
+
 // Error is the error type.
 type Error uint
 
@@ -7,6 +9,10 @@ const (
 	// ErrNoGitTags is the error when no version tags are found in the
 	// repository.
 	ErrNoGitTags Error = iota + 1
+	// ErrGitNotInstalled is the error when the git executable can't be found.
+	ErrGitNotInstalled
+	// ErrCommandFailed is the error when a git command exits with an error.
+	ErrCommandFailed
 )
 
 // Error returns the error string for the error enum.
@@ -15,7 +21,19 @@ func (e Error) Error() string {
 	case ErrNoGitTags:
 		return "no git tags found"
 
+	case ErrGitNotInstalled:
+		return "git is not installed or not on the PATH"
+
+	case ErrCommandFailed:
+		return "git command failed"
+
 	default:
 		return "unknown error"
 	}
 }
+
+// Code returns the stable code for the error enum used in machine readable
+// output.
+func (e Error) Code() string {
+	return "git." + strconv.FormatUint(uint64(e), 10)
+}
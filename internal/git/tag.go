@@ -1,14 +1,16 @@
 package git
 
 import (
+	"context"
 	"strings"
 
 	"github.com/tx3stn/vrsn/internal/version"
 )
 
 // AddTag adds the specified tag.
-func AddTag(dir string, tag string, message string) error {
+func AddTag(ctx context.Context, dir string, tag string, message string) error {
 	_, err := gitCommand(
+		ctx,
 		dir,
 		"error adding tag",
 		"tag", "-a", tag, "-m", message,
@@ -18,8 +20,8 @@ func AddTag(dir string, tag string, message string) error {
 }
 
 // LatestTag returns the latest semantic version tag in the repository.
-func LatestTag(dir string) (string, error) {
-	allTags, err := VersionTags(dir)
+func LatestTag(ctx context.Context, dir string) (string, error) {
+	allTags, err := VersionTags(ctx, dir)
 	if err != nil {
 		return "", err
 	}
@@ -36,8 +38,9 @@ func LatestTag(dir string) (string, error) {
 // lexicographic order (which sorts 0.0.9 after 0.0.10).
 // Tags matching the glob but not parseable as a semantic version (e.g.
 // 1.2.3-rc1) are filtered out so bumping is always based on a valid version.
-func VersionTags(dir string) ([]string, error) {
+func VersionTags(ctx context.Context, dir string) ([]string, error) {
 	all, err := gitCommand(
+		ctx,
 		dir,
 		"error getting version tags",
 		"--no-pager", "tag", "--list", "--sort=v:refname", "*.*.*",
@@ -0,0 +1,29 @@
+package hooks
+
+import "strconv"
+
+// Error is the error type.
+type Error uint
+
+const (
+	// ErrHookFailed is the error when a hook command exits with a non zero
+	// status or can't be started.
+	ErrHookFailed Error = iota + 1
+)
+
+// Error returns the error string for the error enum.
+func (e Error) Error() string {
+	switch e {
+	case ErrHookFailed:
+		return "hook command failed"
+
+	default:
+		return "unknown error"
+	}
+}
+
+// Code returns the stable code for the error enum used in machine readable
+// output.
+func (e Error) Code() string {
+	return "hooks." + strconv.FormatUint(uint64(e), 10)
+}
@@ -0,0 +1,108 @@
+// Package hooks runs the commands configured to run at each stage of writing a
+// new version.
+package hooks
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/tx3stn/vrsn/internal/logger"
+)
+
+// Stage is the point in writing a new version that hooks run at.
+type Stage string
+
+const (
+	// PreBump hooks run after the new version is resolved, before any files are
+	// written.
+	PreBump Stage = "pre-bump"
+	// PostWrite hooks run after the version files are written.
+	PostWrite Stage = "post-write"
+	// PreCommit hooks run before the version files are committed, only when
+	// committing.
+	PreCommit Stage = "pre-commit"
+	// PostTag hooks run after the version tag is created.
+	PostTag Stage = "post-tag"
+)
+
+// Env is the version change exposed to hook commands as environment variables.
+type Env struct {
+	BumpType string
+	// Files are the files vrsn changes, the version files for pre-bump hooks
+	// and every file written, including lockfiles, for the later stages.
+	Files           []string
+	NewVersion      string
+	PreviousVersion string
+}
+
+// vars returns the environment variables for the hook commands of the stage.
+// Files are space separated so they can be passed straight to other commands.
+func (e Env) vars(stage Stage) []string {
+	return []string{
+		"VRSN_HOOK_STAGE=" + string(stage),
+		"VRSN_HOOK_PREVIOUS_VERSION=" + e.PreviousVersion,
+		"VRSN_HOOK_NEW_VERSION=" + e.NewVersion,
+		"VRSN_HOOK_BUMP_TYPE=" + e.BumpType,
+		"VRSN_HOOK_CHANGED_FILES=" + strings.Join(e.Files, " "),
+	}
+}
+
+// Runner runs hook commands in a directory, writing their output to the
+// writers.
+type Runner struct {
+	Dir    string
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// Run runs the commands for the stage in order with the shell, stopping at the
+// first one that fails.
+func (r Runner) Run(ctx context.Context, stage Stage, commands []string, env Env) error {
+	for _, command := range commands {
+		if err := r.run(ctx, stage, command, env); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// run runs the hook command with the shell, sh or cmd on Windows, so commands
+// can use pipes and redirects.
+func (r Runner) run(ctx context.Context, stage Stage, command string, env Env) error {
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+
+	// #nosec G204 -- intentional: hooks are commands the user configured.
+	cmd := exec.CommandContext(ctx, shell, flag, command)
+	cmd.Dir = r.Dir
+	cmd.Env = append(os.Environ(), env.vars(stage)...)
+	cmd.Stdout = r.Stdout
+	cmd.Stderr = r.Stderr
+
+	start := time.Now()
+	err := cmd.Run()
+
+	logger.FromContext(ctx).Debug(
+		"hook command",
+		slog.String("stage", string(stage)),
+		slog.String("command", command),
+		slog.Duration("duration", time.Since(start)),
+		slog.Int("exit_status", cmd.ProcessState.ExitCode()),
+	)
+
+	if err != nil {
+		return fmt.Errorf("%w: %s hook %q: %w", ErrHookFailed, stage, command, err)
+	}
+
+	return nil
+}
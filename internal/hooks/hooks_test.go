@@ -0,0 +1,65 @@
+package hooks_test
+
+import (
+	"bytes"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tx3stn/vrsn/internal/hooks"
+)
+
+func TestRunnerRun(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("hook commands in the test cases use sh syntax")
+	}
+
+	env := hooks.Env{
+		BumpType:        "minor",
+		Files:           []string{"VERSION", "package.json"},
+		NewVersion:      "1.3.0",
+		PreviousVersion: "1.2.3",
+	}
+
+	testCases := map[string]struct {
+		commands       []string
+		expectedOutput string
+		expectedError  error
+	}{
+		"ExposesVersionChangeAsEnvVars": {
+			commands: []string{
+				`echo "$VRSN_HOOK_STAGE $VRSN_HOOK_PREVIOUS_VERSION $VRSN_HOOK_NEW_VERSION $VRSN_HOOK_BUMP_TYPE"`,
+				`echo "$VRSN_HOOK_CHANGED_FILES"`,
+			},
+			expectedOutput: "post-write 1.2.3 1.3.0 minor\nVERSION package.json\n",
+			expectedError:  nil,
+		},
+		"StopsAtFirstFailingCommand": {
+			commands:       []string{"echo first", "exit 3", "echo never"},
+			expectedOutput: "first\n",
+			expectedError:  hooks.ErrHookFailed,
+		},
+		"RunsNothingWithoutCommands": {
+			commands:       nil,
+			expectedOutput: "",
+			expectedError:  nil,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var stdout bytes.Buffer
+
+			runner := hooks.Runner{Dir: t.TempDir(), Stdout: &stdout, Stderr: &stdout}
+
+			err := runner.Run(t.Context(), hooks.PostWrite, tc.commands, env)
+			require.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, tc.expectedOutput, stdout.String())
+		})
+	}
+}
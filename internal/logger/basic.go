@@ -1,47 +1,83 @@
 // Package logger implements a basic logger to support the core level of output
-// and a verbose mode, and maybe some color in future.
+// and a verbose mode, with optional color.
 package logger
 
 import (
 	"fmt"
+	"io"
+	"log/slog"
 	"os"
+	"regexp"
+	"strings"
+	"text/tabwriter"
 )
 
+// ANSI escape codes for the colors used in the output.
+const (
+	colorReset = "\033[0m"
+	colorBold  = "\033[1m"
+	colorRed   = "\033[31m"
+	colorGreen = "\033[32m"
+	colorCyan  = "\033[36m"
+)
+
+// colorCodes matches the ANSI escape codes used for color.
+var colorCodes = regexp.MustCompile("\033\\[[0-9;]*m")
+
 // Basic is a basic logger to provide the simple functionality to allow people
 // to run vrsn in verbose mode and disable/enable color support.
+// The command output is printed to the console, while debug logs are written
+// as structured log records by the slog logger backing it.
 type Basic struct {
+	// Quiet hides info logs, used when the command writes machine readable
+	// output to stdout instead.
+	Quiet bool
+	// Record also writes the info and error logs as log records, used when the
+	// records go to a log file so it holds the full output of the command.
+	Record   bool
 	UseColor bool
-	Verbose  bool
+	// Stdout and Stderr are where the output is written, os.Stdout and
+	// os.Stderr when nil.
+	Stdout io.Writer
+	Stderr io.Writer
+
+	structured *slog.Logger
 }
 
-// NewBasic creates an instance of the Basic logger.
-func NewBasic(color bool, verbose bool) Basic {
+// NewBasic creates an instance of the Basic logger, backed by the structured
+// logger for debug logs.
+func NewBasic(color bool, structured *slog.Logger) Basic {
 	return Basic{
-		UseColor: color,
-		Verbose:  verbose,
+		UseColor:   color,
+		structured: structured,
 	}
 }
 
-// Debug is a log that will only be displayed if the `vrsn` command is run in
-// verbose mode.
-// Debug logs are written to stderr so they don't pollute output that is
-// captured in scripts, e.g. version=$(vrsn get --verbose).
+// Debug is a log that will only be displayed at the debug log level, e.g. when
+// the `vrsn` command is run in verbose mode.
+// Debug logs are written to stderr, or the log file, so they don't pollute
+// output that is captured in scripts, e.g. version=$(vrsn get --verbose).
 func (b Basic) Debug(msg string) {
-	if b.Verbose {
-		fmt.Fprintln(os.Stderr, msg)
-	}
+	b.slog().Debug(msg)
 }
 
-// Debugf is a log that will only be displayed if the `vrsn` command is run in
-// verbose mode, with support for variables.
+// Debugf is a log that will only be displayed at the debug log level, with
+// support for variables.
 func (b Basic) Debugf(msg string, args ...any) {
 	b.Debug(fmt.Sprintf(msg, args...))
 }
 
 // Info is an info level log which will be default always be displayed.
 func (b Basic) Info(msg string) {
-	//nolint:forbidigo
-	fmt.Println(msg)
+	if b.Record {
+		b.slog().Info(stripColor(msg))
+	}
+
+	if b.Quiet {
+		return
+	}
+
+	fmt.Fprintln(b.stdout(), msg)
 }
 
 // Infof is an info level log which will be default always be displayed with
@@ -49,3 +85,89 @@ func (b Basic) Info(msg string) {
 func (b Basic) Infof(msg string, args ...any) {
 	b.Info(fmt.Sprintf(msg, args...))
 }
+
+// Warn is a warn level log of a problem the command recovered from, or is
+// recovering from, such as restoring the version files after a failed commit.
+// Like debug logs, warnings are written to stderr, or the log file, so they
+// don't pollute the command output.
+func (b Basic) Warn(msg string) {
+	b.slog().Warn(msg)
+}
+
+// Success is an info level log for a successful outcome, shown in green when
+// color is enabled.
+func (b Basic) Success(msg string) {
+	b.Info(b.colorize(colorGreen, msg))
+}
+
+// Error logs the error a command failed with to stderr, shown in red when
+// color is enabled. Errors are always displayed, even when Quiet.
+func (b Basic) Error(msg string) {
+	if b.Record {
+		b.slog().Error(msg)
+	}
+
+	fmt.Fprintln(b.stderr(), b.colorize(colorRed, msg))
+}
+
+// Highlight returns the value emphasised so it stands out in a log line, such
+// as the versions in "version bumped from 1.2.3 to 1.3.0".
+func (b Basic) Highlight(value string) string {
+	return b.colorize(colorBold+colorCyan, value)
+}
+
+// Table is an info level log of the rows with their columns aligned.
+func (b Basic) Table(rows [][]string) {
+	var table strings.Builder
+
+	writer := tabwriter.NewWriter(&table, 0, 0, 2, ' ', 0)
+
+	for _, row := range rows {
+		fmt.Fprintln(writer, "  "+strings.Join(row, "\t"))
+	}
+
+	// Writing to a strings.Builder can't fail.
+	_ = writer.Flush()
+
+	b.Info(strings.TrimSuffix(table.String(), "\n"))
+}
+
+func (b Basic) stdout() io.Writer {
+	if b.Stdout == nil {
+		return os.Stdout
+	}
+
+	return b.Stdout
+}
+
+func (b Basic) stderr() io.Writer {
+	if b.Stderr == nil {
+		return os.Stderr
+	}
+
+	return b.Stderr
+}
+
+// slog returns the structured logger backing the logger, falling back to the
+// default logger for the zero value.
+func (b Basic) slog() *slog.Logger {
+	if b.structured == nil {
+		return slog.Default()
+	}
+
+	return b.structured
+}
+
+// stripColor removes the color codes from the message so log records are
+// plain text, even when the message contains highlighted values.
+func stripColor(msg string) string {
+	return colorCodes.ReplaceAllString(msg, "")
+}
+
+func (b Basic) colorize(color string, msg string) string {
+	if !b.UseColor {
+		return msg
+	}
+
+	return color + msg + colorReset
+}
@@ -0,0 +1,58 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// Color modes for the --color flag.
+const (
+	// ColorAuto uses color when writing to a terminal and NO_COLOR isn't set.
+	ColorAuto = "auto"
+	// ColorAlways always uses color.
+	ColorAlways = "always"
+	// ColorNever never uses color.
+	ColorNever = "never"
+)
+
+// ValidateColorMode checks the --color flag value is a supported mode.
+func ValidateColorMode(mode string) error {
+	switch mode {
+	case "", ColorAuto, ColorAlways, ColorNever:
+		return nil
+
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidColorMode, mode)
+	}
+}
+
+// ColorEnabled reports whether output written to the writer should use color
+// in the mode. In auto mode, which is also used for an empty mode, color is
+// used when the writer is a terminal, unless NO_COLOR is set (see
+// no-color.org) or the terminal is dumb.
+func ColorEnabled(mode string, writer io.Writer) bool {
+	switch mode {
+	case ColorAlways:
+		return true
+
+	case ColorNever:
+		return false
+	}
+
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+
+	file, ok := writer.(*os.File)
+	if !ok {
+		return false
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,118 @@
+package logger_test
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tx3stn/vrsn/internal/logger"
+)
+
+func TestValidateColorMode(t *testing.T) {
+	testCases := map[string]struct {
+		mode          string
+		expectedError error
+	}{
+		"AcceptsEmptyMode": {
+			mode:          "",
+			expectedError: nil,
+		},
+		"AcceptsAuto": {
+			mode:          "auto",
+			expectedError: nil,
+		},
+		"AcceptsAlways": {
+			mode:          "always",
+			expectedError: nil,
+		},
+		"AcceptsNever": {
+			mode:          "never",
+			expectedError: nil,
+		},
+		"RejectsUnknownMode": {
+			mode:          "sometimes",
+			expectedError: logger.ErrInvalidColorMode,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := logger.ValidateColorMode(tc.mode)
+			require.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestColorEnabled(t *testing.T) {
+	testCases := map[string]struct {
+		mode     string
+		noColor  string
+		expected bool
+	}{
+		"AlwaysIgnoresNoColor": {
+			mode:     "always",
+			noColor:  "1",
+			expected: true,
+		},
+		"NeverDisablesColor": {
+			mode:     "never",
+			noColor:  "",
+			expected: false,
+		},
+		"AutoDisablesColorWhenNoColorIsSet": {
+			mode:     "auto",
+			noColor:  "1",
+			expected: false,
+		},
+		"AutoDisablesColorWhenNotATerminal": {
+			mode:     "auto",
+			noColor:  "",
+			expected: false,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("NO_COLOR", tc.noColor)
+
+			file, err := os.Create(filepath.Join(t.TempDir(), "output"))
+			require.NoError(t, err)
+
+			t.Cleanup(func() {
+				_ = file.Close()
+			})
+
+			assert.Equal(t, tc.expected, logger.ColorEnabled(tc.mode, file))
+		})
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	testCases := map[string]struct {
+		useColor bool
+		expected string
+	}{
+		"WrapsValueInColorCodes": {
+			useColor: true,
+			expected: "\033[1m\033[36m1.2.3\033[0m",
+		},
+		"ReturnsPlainValueWithoutColor": {
+			useColor: false,
+			expected: "1.2.3",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			log := logger.NewBasic(tc.useColor, slog.New(slog.DiscardHandler))
+			assert.Equal(t, tc.expected, log.Highlight("1.2.3"))
+		})
+	}
+}
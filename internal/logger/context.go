@@ -0,0 +1,24 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+)
+
+type contextKey struct{}
+
+// NewContext returns a copy of the context carrying the structured logger, for
+// packages that log without a logger passed to them, e.g. git.
+func NewContext(ctx context.Context, structured *slog.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, structured)
+}
+
+// FromContext returns the structured logger carried by the context, falling
+// back to the default logger when there isn't one.
+func FromContext(ctx context.Context) *slog.Logger {
+	if structured, ok := ctx.Value(contextKey{}).(*slog.Logger); ok {
+		return structured
+	}
+
+	return slog.Default()
+}
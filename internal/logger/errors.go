@@ -0,0 +1,47 @@
+package logger
+
+import "strconv"
+
+// Error is the error type.
+type Error uint
+
+const (
+	// ErrInvalidColorMode is the error when the --color flag is not a
+	// supported color mode.
+	ErrInvalidColorMode Error = iota + 1
+	// ErrInvalidLogFormat is the error when the --log-format flag is not a
+	// supported log format.
+	ErrInvalidLogFormat
+	// ErrInvalidLogLevel is the error when the --log-level flag is not a
+	// supported log level.
+	ErrInvalidLogLevel
+	// ErrOpeningLogFile is the error when the --log-file can't be opened to
+	// write logs to.
+	ErrOpeningLogFile
+)
+
+// Error returns the error string for the error enum.
+func (e Error) Error() string {
+	switch e {
+	case ErrInvalidColorMode:
+		return "invalid color mode, must be one of: auto, always, never"
+
+	case ErrInvalidLogFormat:
+		return "invalid log format, must be one of: text, json"
+
+	case ErrInvalidLogLevel:
+		return "invalid log level, must be one of: debug, info, warn, error"
+
+	case ErrOpeningLogFile:
+		return "error opening log file"
+
+	default:
+		return "unknown error"
+	}
+}
+
+// Code returns the stable code for the error enum used in machine readable
+// output.
+func (e Error) Code() string {
+	return "logger." + strconv.FormatUint(uint64(e), 10)
+}
@@ -0,0 +1,25 @@
+package logger
+
+// Logger is the output vrsn writes as a command runs: the info logs that are
+// the result of the command, the error it failed with, and debug logs to help
+// diagnose what the command is doing.
+type Logger interface {
+	// Debug logs a diagnostic message, only shown at the debug log level.
+	Debug(msg string)
+	// Debugf logs a diagnostic message with support for variables.
+	Debugf(msg string, args ...any)
+	// Info logs the output of the command.
+	Info(msg string)
+	// Infof logs the output of the command with support for variables.
+	Infof(msg string, args ...any)
+	// Warn logs a problem the command recovered from, or is recovering from.
+	Warn(msg string)
+	// Success logs a successful outcome of the command.
+	Success(msg string)
+	// Error logs the error the command failed with.
+	Error(msg string)
+	// Highlight returns the value emphasised so it stands out in a log line.
+	Highlight(value string) string
+	// Table logs the rows with their columns aligned.
+	Table(rows [][]string)
+}
@@ -0,0 +1,110 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+)
+
+// Log formats for the --log-format flag.
+const (
+	// FormatText writes log records as key=value pairs.
+	FormatText = "text"
+	// FormatJSON writes log records as JSON objects, one per line, for log
+	// aggregation in CI.
+	FormatJSON = "json"
+)
+
+// StructuredOptions configure the structured log records written alongside
+// the command output.
+type StructuredOptions struct {
+	// File is the path log records are appended to, when empty they are
+	// written to the console instead.
+	File string
+	// Format is the FormatText or FormatJSON format of the records.
+	Format string
+	// Level is the minimum level of the records written, e.g. info.
+	Level string
+	// Verbose lowers the level to debug, whatever Level is set to.
+	Verbose bool
+}
+
+// ParseLevel parses the --log-level flag value, one of debug, info, warn or
+// error. An empty level is the info level.
+func ParseLevel(level string) (slog.Level, error) {
+	if level == "" {
+		return slog.LevelInfo, nil
+	}
+
+	var parsed slog.Level
+
+	if err := parsed.UnmarshalText([]byte(level)); err != nil {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidLogLevel, level)
+	}
+
+	return parsed, nil
+}
+
+// ValidateLogFormat checks the --log-format flag value is a supported format.
+func ValidateLogFormat(format string) error {
+	switch format {
+	case "", FormatText, FormatJSON:
+		return nil
+
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidLogFormat, format)
+	}
+}
+
+// NewStructured creates the slog logger for the options, writing to console
+// unless a log file is set. The returned close func must be called once
+// logging is done to close the log file.
+func NewStructured(opts StructuredOptions, console io.Writer) (*slog.Logger, func() error, error) {
+	level, err := ParseLevel(opts.Level)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if opts.Verbose {
+		level = min(level, slog.LevelDebug)
+	}
+
+	if err := ValidateLogFormat(opts.Format); err != nil {
+		return nil, nil, err
+	}
+
+	handlerOpts := &slog.HandlerOptions{Level: level}
+	out := console
+	closeLog := func() error { return nil }
+
+	if opts.File != "" {
+		file, err := os.OpenFile(filepath.Clean(opts.File), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %w", ErrOpeningLogFile, err)
+		}
+
+		out = file
+		closeLog = file.Close
+	}
+
+	if opts.Format == FormatJSON {
+		return slog.New(slog.NewJSONHandler(out, handlerOpts)), closeLog, nil
+	}
+
+	if opts.File == "" {
+		// The time of each record is noise when reading logs in a terminal.
+		handlerOpts.ReplaceAttr = dropTime
+	}
+
+	return slog.New(slog.NewTextHandler(out, handlerOpts)), closeLog, nil
+}
+
+func dropTime(groups []string, attr slog.Attr) slog.Attr {
+	if attr.Key == slog.TimeKey && len(groups) == 0 {
+		return slog.Attr{}
+	}
+
+	return attr
+}
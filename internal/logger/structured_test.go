@@ -0,0 +1,127 @@
+package logger_test
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tx3stn/vrsn/internal/logger"
+)
+
+func TestParseLevel(t *testing.T) {
+	testCases := map[string]struct {
+		level         string
+		expected      slog.Level
+		expectedError error
+	}{
+		"DefaultsToInfo": {
+			level:         "",
+			expected:      slog.LevelInfo,
+			expectedError: nil,
+		},
+		"ParsesDebug": {
+			level:         "debug",
+			expected:      slog.LevelDebug,
+			expectedError: nil,
+		},
+		"ParsesUpperCase": {
+			level:         "WARN",
+			expected:      slog.LevelWarn,
+			expectedError: nil,
+		},
+		"RejectsUnknownLevel": {
+			level:         "loud",
+			expected:      0,
+			expectedError: logger.ErrInvalidLogLevel,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			level, err := logger.ParseLevel(tc.level)
+			require.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, tc.expected, level)
+		})
+	}
+}
+
+func TestNewStructured(t *testing.T) {
+	testCases := map[string]struct {
+		opts     logger.StructuredOptions
+		expected string
+	}{
+		"WritesTextWithoutTimeToConsole": {
+			opts:     logger.StructuredOptions{Format: "text", Level: "info"},
+			expected: "level=INFO msg=hello\n",
+		},
+		"FiltersRecordsBelowLevel": {
+			opts:     logger.StructuredOptions{Format: "text", Level: "warn"},
+			expected: "",
+		},
+		"VerboseLowersLevelToDebug": {
+			opts:     logger.StructuredOptions{Format: "text", Level: "error", Verbose: true},
+			expected: "level=INFO msg=hello\n",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var console bytes.Buffer
+
+			structured, closeLog, err := logger.NewStructured(tc.opts, &console)
+			require.NoError(t, err)
+
+			structured.Info("hello")
+
+			require.NoError(t, closeLog())
+			assert.Equal(t, tc.expected, console.String())
+		})
+	}
+}
+
+func TestNewStructuredWritesToLogFile(t *testing.T) {
+	t.Parallel()
+
+	var console bytes.Buffer
+
+	logFile := filepath.Join(t.TempDir(), "vrsn.log")
+
+	structured, closeLog, err := logger.NewStructured(
+		logger.StructuredOptions{File: logFile, Format: "json", Level: "debug"},
+		&console,
+	)
+	require.NoError(t, err)
+
+	log := logger.NewBasic(true, structured)
+	log.Quiet = true
+	log.Record = true
+
+	log.Debug("checking files")
+	log.Info("was: " + log.Highlight("1.2.3"))
+	log.Warn("restoring version files")
+
+	require.NoError(t, closeLog())
+
+	content, err := os.ReadFile(logFile)
+	require.NoError(t, err)
+
+	assert.Empty(t, console.String())
+	assert.Contains(t, string(content), `"level":"DEBUG","msg":"checking files"`)
+	assert.Contains(t, string(content), `"level":"INFO","msg":"was: 1.2.3"`)
+	assert.Contains(t, string(content), `"level":"WARN","msg":"restoring version files"`)
+}
+
+func TestNewStructuredRejectsInvalidFormat(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := logger.NewStructured(logger.StructuredOptions{Format: "xml"}, &bytes.Buffer{})
+	require.ErrorIs(t, err, logger.ErrInvalidLogFormat)
+}
@@ -0,0 +1,29 @@
+package output
+
+import "strconv"
+
+// Error is the error type.
+type Error uint
+
+const (
+	// ErrInvalidFormat is the error when the --output flag is not a supported
+	// output format.
+	ErrInvalidFormat Error = iota + 1
+)
+
+// Error returns the error string for the error enum.
+func (e Error) Error() string {
+	switch e {
+	case ErrInvalidFormat:
+		return "invalid output format, must be one of: text, json"
+
+	default:
+		return "unknown error"
+	}
+}
+
+// Code returns the stable code for the error enum used in machine readable
+// output.
+func (e Error) Code() string {
+	return "output." + strconv.FormatUint(uint64(e), 10)
+}
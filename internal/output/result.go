@@ -0,0 +1,169 @@
+// Package output renders the result of a command in a machine readable format.
+package output
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Format is the format command results are written in.
+type Format string
+
+const (
+	// Text is the default human readable log output.
+	Text Format = "text"
+	// JSON writes a single JSON document describing the result of the command.
+	JSON Format = "json"
+)
+
+// ParseFormat validates the --output flag value.
+func ParseFormat(value string) (Format, error) {
+	switch Format(value) {
+	case Text, JSON:
+		return Format(value), nil
+
+	default:
+		return "", fmt.Errorf("%w: %s", ErrInvalidFormat, value)
+	}
+}
+
+// Result is the machine readable result of a command. Fields that don't apply
+// to the command are omitted.
+type Result struct {
+	Command string `json:"command"`
+	// Files is the version found in, or written to, each version file.
+	Files []FileVersion `json:"files,omitempty"`
+	// Version is the current version for get, or the new version for bump and
+	// set.
+	Version string `json:"version,omitempty"`
+	// BuildNumber is the build number for get --build-number, set when every
+	// file has the same one.
+	BuildNumber string `json:"build_number,omitempty"`
+	// PreviousVersion is the version before bump or set, or the was version for
+	// check.
+	PreviousVersion string `json:"previous_version,omitempty"`
+	// BumpType is the increment from the previous version to the version, one
+	// of patch, minor or major.
+	BumpType string `json:"bump_type,omitempty"`
+	// Candidates is the next version for each increment type, keyed by the
+	// type, set by next --all.
+	Candidates map[string]string `json:"candidates,omitempty"`
+	// Comparison is the result of compare, one of lt, eq or gt.
+	Comparison string `json:"comparison,omitempty"`
+	// Versions are the versions in order, set by sort.
+	Versions []string `json:"versions,omitempty"`
+	// Constraint is the version range checked by satisfies.
+	Constraint string `json:"constraint,omitempty"`
+	// Satisfies reports whether the version is in the constraint range.
+	Satisfies *bool `json:"satisfies,omitempty"`
+	// Branch is the current branch when a check branch policy applied to it.
+	Branch string `json:"branch,omitempty"`
+	// Policy is the pattern of the branch policy check applied.
+	Policy string `json:"policy,omitempty"`
+	// Commit is the SHA of the commit created with --commit.
+	Commit string `json:"commit,omitempty"`
+	// Tag is the git tag created with --git-tag.
+	Tag string `json:"tag,omitempty"`
+	// Sources are the version in each source compared by check --consistency.
+	Sources []Source `json:"sources,omitempty"`
+	// Settings are the effective config options, set by config show.
+	Settings []Setting `json:"settings,omitempty"`
+	// ConfigFiles are the config files validated by config validate, or
+	// written by config init.
+	ConfigFiles []string `json:"config_files,omitempty"`
+	// Problems are the issues config validate found in the config files.
+	Problems []Problem    `json:"problems,omitempty"`
+	DryRun   bool         `json:"dry_run,omitempty"`
+	Error    *ErrorDetail `json:"error,omitempty"`
+}
+
+// FileVersion is the version in a single version file.
+type FileVersion struct {
+	File            string `json:"file"`
+	Version         string `json:"version,omitempty"`
+	PreviousVersion string `json:"previous_version,omitempty"`
+	// BuildNumber is the build number stored alongside the version, only set
+	// by get --build-number.
+	BuildNumber string `json:"build_number,omitempty"`
+	// Diff is the unified diff of the change that would be made, only set for
+	// dry runs.
+	Diff string `json:"diff,omitempty"`
+}
+
+// Source is the version in a single source compared by check --consistency,
+// e.g. a version file or the latest git tag.
+type Source struct {
+	Source  string `json:"source"`
+	Version string `json:"version"`
+	// Expected is the version the source should have, the version most of the
+	// sources have or the build number derived from the version.
+	Expected string `json:"expected"`
+	Matches  bool   `json:"matches"`
+}
+
+// Setting is the value of a single config option.
+type Setting struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	// Origin is where the value came from, only set with --origin.
+	Origin string `json:"origin,omitempty"`
+}
+
+// Problem is an issue found in a config file.
+type Problem struct {
+	File    string `json:"file"`
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+	Key     string `json:"key,omitempty"`
+	Message string `json:"message"`
+}
+
+// ErrorDetail describes the error a command failed with.
+type ErrorDetail struct {
+	// Code is the stable code of the first typed error in the chain, or
+	// "unknown" for errors that don't have one.
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	// ExitCode is the exit code the command exits with for the error.
+	ExitCode int `json:"exit_code"`
+}
+
+// coder is implemented by the Error enums of each package. Codes are the
+// package name and enum value, e.g. files.14, so new errors are only ever added
+// to the end of an enum to keep existing codes stable.
+type coder interface {
+	Code() string
+}
+
+// NewError returns the error details for the error, or nil if there is no
+// error.
+func NewError(err error, exitCode int) *ErrorDetail {
+	if err == nil {
+		return nil
+	}
+
+	errCode := "unknown"
+
+	var typed coder
+	if errors.As(err, &typed) {
+		errCode = typed.Code()
+	}
+
+	return &ErrorDetail{Code: errCode, Message: err.Error(), ExitCode: exitCode}
+}
+
+// Write writes the result as an indented JSON document.
+func Write(w io.Writer, result Result) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	// Ranges such as <2.0.0 are output as written rather than escaped.
+	encoder.SetEscapeHTML(false)
+
+	if err := encoder.Encode(result); err != nil {
+		return fmt.Errorf("error encoding output: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package output_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tx3stn/vrsn/internal/files"
+	"github.com/tx3stn/vrsn/internal/output"
+	"github.com/tx3stn/vrsn/internal/version"
+)
+
+func TestParseFormat(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		value         string
+		expected      output.Format
+		expectedError error
+	}{
+		"ParsesText": {
+			value:         "text",
+			expected:      output.Text,
+			expectedError: nil,
+		},
+		"ParsesJSON": {
+			value:         "json",
+			expected:      output.JSON,
+			expectedError: nil,
+		},
+		"ReturnsErrorForUnsupportedFormat": {
+			value:         "yaml",
+			expected:      "",
+			expectedError: output.ErrInvalidFormat,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := output.ParseFormat(tc.value)
+			require.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestNewError(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		err      error
+		expected *output.ErrorDetail
+	}{
+		"ReturnsNilWithoutError": {
+			err:      nil,
+			expected: nil,
+		},
+		"ReturnsCodeOfWrappedError": {
+			err: fmt.Errorf("error comparing versions: %w", version.ErrVersionNotBumped),
+			expected: &output.ErrorDetail{
+				Code:     "version.5",
+				Message:  "error comparing versions: version has not been bumped",
+				ExitCode: 4,
+			},
+		},
+		"ReturnsCodeFromOtherPackages": {
+			err: files.ErrVersionsDoNotMatch,
+			expected: &output.ErrorDetail{
+				Code:     "files.14",
+				Message:  files.ErrVersionsDoNotMatch.Error(),
+				ExitCode: 4,
+			},
+		},
+		"ReturnsUnknownCodeForUntypedError": {
+			err: errors.New("boom"),
+			expected: &output.ErrorDetail{
+				Code:     "unknown",
+				Message:  "boom",
+				ExitCode: 4,
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, output.NewError(tc.err, 4))
+		})
+	}
+}
+
+func TestWriteOmitsEmptyFields(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	err := output.Write(&buf, output.Result{Command: "get", Version: "1.2.3"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"command": "get", "version": "1.2.3"}`, buf.String())
+}
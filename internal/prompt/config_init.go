@@ -0,0 +1,62 @@
+package prompt
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/charmbracelet/huh"
+	"github.com/mattn/go-isatty"
+	"github.com/tx3stn/vrsn/internal/config"
+)
+
+// IsInteractive reports whether the input is a terminal a user can answer
+// prompts from.
+func IsInteractive(input io.Reader) bool {
+	file, ok := input.(*os.File)
+	if !ok {
+		return false
+	}
+
+	return isatty.IsTerminal(file.Fd()) || isatty.IsCygwinTerminal(file.Fd())
+}
+
+// ConfigInit prompts the user for the options of a new config file, offering
+// the version files detected in the project, with the defaults preselected.
+func ConfigInit(
+	ctx context.Context,
+	detected []string,
+	defaults config.InitOptions,
+) (config.InitOptions, error) {
+	opts := defaults
+	groups := []*huh.Group{}
+
+	if len(detected) > 0 {
+		options := make([]huh.Option[string], 0, len(detected))
+		for _, file := range detected {
+			options = append(options, huh.NewOption(file, file).Selected(slices.Contains(defaults.Files, file)))
+		}
+
+		groups = append(groups, huh.NewGroup(
+			huh.NewMultiSelect[string]().
+				Options(options...).
+				Title("version files to keep in sync:").
+				Value(&opts.Files),
+		))
+	}
+
+	groups = append(groups, huh.NewGroup(
+		huh.NewConfirm().Title("commit version files after a bump?").Value(&opts.Commit),
+		huh.NewConfirm().Title("version with git tags instead of files?").Value(&opts.GitTag),
+		huh.NewInput().Title("base branch:").Value(&opts.BaseBranch),
+	))
+
+	form := huh.NewForm(groups...).WithAccessible(os.Getenv("ACCESSIBLE") != "")
+	if err := form.RunWithContext(ctx); err != nil {
+		return config.InitOptions{}, fmt.Errorf("error prompting for config options: %w", err)
+	}
+
+	return opts, nil
+}
@@ -1,5 +1,7 @@
 package prompt
 
+import "strconv"
+
 // Error is the error type.
 type Error uint8
 
@@ -19,3 +21,9 @@ func (e Error) Error() string {
 		return "unknown error"
 	}
 }
+
+// Code returns the stable code for the error enum used in machine readable
+// output.
+func (e Error) Code() string {
+	return "prompt." + strconv.FormatUint(uint64(e), 10)
+}
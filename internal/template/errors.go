@@ -1,5 +1,7 @@
 package template
 
+import "strconv"
+
 // Error is the error type.
 type Error uint
 
@@ -10,6 +12,9 @@ const (
 	// ErrRenderingTemplate is the error when the message template cannot be
 	// rendered, such as when it references an unsupported variable.
 	ErrRenderingTemplate
+	// ErrNoRepository is the error when a template uses a git variable, such
+	// as {{.Branch}}, outside of a git repository.
+	ErrNoRepository
 )
 
 // Error returns the error string for the error enum.
@@ -21,7 +26,16 @@ func (e Error) Error() string {
 	case ErrRenderingTemplate:
 		return "error rendering message template"
 
+	case ErrNoRepository:
+		return "git variables are only available in a git repository"
+
 	default:
 		return "unknown error"
 	}
 }
+
+// Code returns the stable code for the error enum used in machine readable
+// output.
+func (e Error) Code() string {
+	return "template." + strconv.FormatUint(uint64(e), 10)
+}
@@ -4,25 +4,128 @@ package template
 import (
 	"bytes"
 	"fmt"
+	"strings"
 	"text/template"
+	"time"
+
+	"github.com/tx3stn/vrsn/internal/version"
 )
 
+// Repo looks up the details of the git repository a version is written in.
+// They are only looked up when a template uses them, so messages that don't
+// need them don't run any git commands.
+type Repo interface {
+	// Branch returns the name of the current branch.
+	Branch() (string, error)
+	// Commits returns the subject of each commit since the previous version,
+	// newest first.
+	Commits() ([]string, error)
+	// Package returns the path of the current directory relative to the root
+	// of the repository, empty at the root.
+	Package() (string, error)
+}
+
 // Data holds the variables available to message templates.
 type Data struct {
+	// Version is the new version.
 	Version string
+	// Previous is the version before the change.
+	Previous string
+	// Major, Minor and Patch are the parts of the new version, zero when it
+	// isn't a valid semantic version.
+	Major int
+	Minor int
+	Patch int
+	// BumpType is patch, minor or major, empty when the change isn't a
+	// single valid increment, e.g. with set.
+	BumpType string
+	// Files are the version files the new version is written to, empty when
+	// bumping a git tag.
+	Files []string
+	// Date is when the new version is written.
+	Date time.Time
+
+	repo Repo
+}
+
+// NewData returns the template data for the change from the previous to the
+// new version, looking up the git variables in the repo.
+func NewData(previous string, newVersion string, repo Repo) Data {
+	data := Data{
+		Version:  newVersion,
+		Previous: previous,
+		Date:     time.Now(),
+		repo:     repo,
+	}
+
+	if semVer, err := version.Parse(newVersion); err == nil {
+		data.Major = semVer.Major
+		data.Minor = semVer.Minor
+		data.Patch = semVer.Patch
+	}
+
+	if bumpType, err := version.BumpType(previous, newVersion); err == nil {
+		data.BumpType = bumpType
+	}
+
+	return data
+}
+
+// Branch returns the name of the current branch, used as {{.Branch}}.
+func (d Data) Branch() (string, error) {
+	if d.repo == nil {
+		return "", ErrNoRepository
+	}
+
+	//nolint:wrapcheck
+	return d.repo.Branch()
+}
+
+// Commits returns the subject of each commit since the previous version, used
+// as {{.Commits}}.
+func (d Data) Commits() ([]string, error) {
+	if d.repo == nil {
+		return nil, ErrNoRepository
+	}
+
+	//nolint:wrapcheck
+	return d.repo.Commits()
+}
+
+// Package returns the directory of the package being versioned relative to the
+// root of the repository, used as {{.Package}} in monorepos.
+func (d Data) Package() (string, error) {
+	if d.repo == nil {
+		return "", ErrNoRepository
+	}
+
+	//nolint:wrapcheck
+	return d.repo.Package()
+}
+
+// funcs returns the functions available to message templates. The value being
+// transformed is the last argument so they can be used in pipelines, e.g.
+// {{.Version | trimPrefix "v"}}.
+func funcs(data Data) template.FuncMap {
+	return template.FuncMap{
+		"date":       data.Date.Format,
+		"join":       func(sep string, items []string) string { return strings.Join(items, sep) },
+		"trimPrefix": func(prefix string, s string) string { return strings.TrimPrefix(s, prefix) },
+		"upper":      strings.ToUpper,
+	}
 }
 
-// Render renders the provided message template, exposing the new version via
-// the {{.Version}} template variable. Messages that don't use any template
-// syntax are returned unchanged.
-func Render(msg string, version string) (string, error) {
-	tmpl, err := template.New("message").Option("missingkey=error").Parse(msg)
+// Render renders the provided message template with the data, see Data for the
+// variables and funcs for the functions available. Messages that don't use any
+// template syntax are returned unchanged.
+func Render(msg string, data Data) (string, error) {
+	tmpl, err := template.New("message").Funcs(funcs(data)).Option("missingkey=error").Parse(msg)
 	if err != nil {
 		return "", fmt.Errorf("%w: %w", ErrParsingTemplate, err)
 	}
 
 	var rendered bytes.Buffer
-	if err := tmpl.Execute(&rendered, Data{Version: version}); err != nil {
+	if err := tmpl.Execute(&rendered, data); err != nil {
 		return "", fmt.Errorf("%w: %w", ErrRenderingTemplate, err)
 	}
 
@@ -1,7 +1,9 @@
 package template_test
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -13,46 +15,76 @@ func TestRender(t *testing.T) {
 
 	testCases := map[string]struct {
 		message       string
-		version       string
+		data          template.Data
 		expected      string
 		expectedError error
 	}{
 		"ReturnsMessageWithoutTemplateSyntaxUnchanged": {
 			message:       "bump version",
-			version:       "1.0.0",
+			data:          template.Data{Version: "1.0.0"},
 			expected:      "bump version",
 			expectedError: nil,
 		},
 		"RendersVersionVariable": {
 			message:       "release {{.Version}}",
-			version:       "1.2.3",
+			data:          template.Data{Version: "1.2.3"},
 			expected:      "release 1.2.3",
 			expectedError: nil,
 		},
 		"RendersVersionVariableWithPrefix": {
 			message:       "bump version to {{.Version}}",
-			version:       "v1.2.3",
+			data:          template.Data{Version: "v1.2.3"},
 			expected:      "bump version to v1.2.3",
 			expectedError: nil,
 		},
 		"RendersVersionVariableWithSpacing": {
 			message:       "release {{ .Version }}",
-			version:       "0.1.0",
+			data:          template.Data{Version: "0.1.0"},
 			expected:      "release 0.1.0",
 			expectedError: nil,
 		},
 		"ReturnsErrorForInvalidTemplateSyntax": {
 			message:       "release {{.Version",
-			version:       "1.2.3",
+			data:          template.Data{Version: "1.2.3"},
 			expected:      "",
 			expectedError: template.ErrParsingTemplate,
 		},
 		"ReturnsErrorForUnsupportedVariable": {
 			message:       "release {{.NotAThing}}",
-			version:       "1.2.3",
+			data:          template.Data{Version: "1.2.3"},
 			expected:      "",
 			expectedError: template.ErrRenderingTemplate,
 		},
+		"RendersVersionParts": {
+			message:       "{{.Previous}} -> {{.Major}}.{{.Minor}}.{{.Patch}} ({{.BumpType}})",
+			data:          template.NewData("1.2.3", "v1.3.0", nil),
+			expected:      "1.2.3 -> 1.3.0 (minor)",
+			expectedError: nil,
+		},
+		"RendersFunctions": {
+			message:       `{{.BumpType | upper}} {{.Version | trimPrefix "v"}} {{date "2006-01-02"}}: {{join ", " .Files}}`,
+			data:          withFiles(template.NewData("v1.2.3", "v1.2.4", nil), "VERSION", "package.json"),
+			expected:      "PATCH 1.2.4 2026-01-02: VERSION, package.json",
+			expectedError: nil,
+		},
+		"RendersGitVariables": {
+			message:       "{{.Package}}@{{.Version}} on {{.Branch}}\n{{range .Commits}}- {{.}}\n{{end}}",
+			data:          template.NewData("1.0.0", "1.0.1", fakeRepo{}),
+			expected:      "services/api@1.0.1 on main\n- fix login\n- fix logout\n",
+			expectedError: nil,
+		},
+		"ReturnsErrorForGitVariableWithoutRepository": {
+			message:       "release {{.Branch}}",
+			data:          template.NewData("1.0.0", "1.0.1", nil),
+			expected:      "",
+			expectedError: template.ErrNoRepository,
+		},
+		"ReturnsErrorFromRepository": {
+			message:       "release {{.Commits}}",
+			data:          template.NewData("1.0.0", "1.0.1", fakeRepo{err: errFake}),
+			expected:      "",
+			expectedError: errFake,
+		},
 	}
 
 	for name, testCase := range testCases {
@@ -61,9 +93,30 @@ func TestRender(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 
-			rendered, err := template.Render(tc.message, tc.version)
+			rendered, err := template.Render(tc.message, tc.data)
 			require.ErrorIs(t, err, tc.expectedError)
 			assert.Equal(t, tc.expected, rendered)
 		})
 	}
 }
+
+var errFake = errors.New("fake error")
+
+type fakeRepo struct {
+	err error
+}
+
+func (f fakeRepo) Branch() (string, error) { return "main", f.err }
+
+func (f fakeRepo) Commits() ([]string, error) { return []string{"fix login", "fix logout"}, f.err }
+
+func (f fakeRepo) Package() (string, error) { return "services/api", f.err }
+
+// withFiles sets the files and a fixed date on the data so the output is
+// stable.
+func withFiles(data template.Data, files ...string) template.Data {
+	data.Files = files
+	data.Date = time.Date(2026, time.January, 2, 0, 0, 0, 0, time.UTC)
+
+	return data
+}
@@ -0,0 +1,27 @@
+package version
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// NumericCode derives the integer build number code from the version, see
+// SemVer.BuildNumber, as used for android:versionCode and Apple build numbers.
+// Any set suffix (e.g. the "-dev" in 1.2.3-dev) is dropped before parsing,
+// since the code is an integer.
+func NumericCode(input string, minorDigits int, patchDigits int) (string, error) {
+	core, _, _ := strings.Cut(input, "-")
+
+	parsed, err := Parse(core)
+	if err != nil {
+		return "", fmt.Errorf("error parsing version: %w", err)
+	}
+
+	code, err := parsed.BuildNumber(minorDigits, patchDigits)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(code), nil
+}
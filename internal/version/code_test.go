@@ -0,0 +1,43 @@
+package version_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tx3stn/vrsn/internal/version"
+)
+
+func TestNumericCode(t *testing.T) {
+	testCases := map[string]struct {
+		input         string
+		expected      string
+		expectedError error
+	}{
+		"DerivesCodeFromVersion": {
+			input:         "1.2.3",
+			expected:      "10203",
+			expectedError: nil,
+		},
+		"DropsSuffix": {
+			input:         "v2.10.0-dev",
+			expected:      "21000",
+			expectedError: nil,
+		},
+		"ErrorsForInvalidVersion": {
+			input:         "1.2",
+			expected:      "",
+			expectedError: version.ErrNumVersionParts,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			code, err := version.NumericCode(tc.input, 2, 2)
+			require.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, tc.expected, code)
+		})
+	}
+}
@@ -3,31 +3,40 @@ package version
 // Compare compares the provided versions to see if the increase is a valid
 // semver increment.
 func Compare(wasInput string, nowInput string) error {
+	_, err := BumpType(wasInput, nowInput)
+
+	return err
+}
+
+// BumpType returns the type of increment ("patch", "minor" or "major") from
+// the was version to the now version, or an error if the change isn't a valid
+// semver increment.
+func BumpType(wasInput string, nowInput string) (string, error) {
 	if wasInput == nowInput {
-		return ErrVersionNotBumped
+		return "", ErrVersionNotBumped
 	}
 
 	was, err := Parse(wasInput)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	now, err := Parse(nowInput)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	if IsValidPatch(was, now) {
-		return nil
+		return "patch", nil
 	}
 
 	if IsValidMinor(was, now) {
-		return nil
+		return "minor", nil
 	}
 
 	if IsValidMajor(was, now) {
-		return nil
+		return "major", nil
 	}
 
-	return ErrInvalidBump
+	return "", ErrInvalidBump
 }
@@ -3,6 +3,7 @@ package version_test
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/tx3stn/vrsn/internal/version"
 )
@@ -68,3 +69,55 @@ func TestCompare(t *testing.T) {
 		})
 	}
 }
+
+func TestBumpType(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		was           string
+		now           string
+		expected      string
+		expectedError error
+	}{
+		"ReturnsPatch": {
+			was:           "1.0.0",
+			now:           "1.0.1",
+			expected:      "patch",
+			expectedError: nil,
+		},
+		"ReturnsMinor": {
+			was:           "1.0.3",
+			now:           "1.1.0",
+			expected:      "minor",
+			expectedError: nil,
+		},
+		"ReturnsMajor": {
+			was:           "v1.2.3",
+			now:           "v2.0.0",
+			expected:      "major",
+			expectedError: nil,
+		},
+		"ReturnsErrorForInvalidBump": {
+			was:           "1.0.0",
+			now:           "1.0.3",
+			expected:      "",
+			expectedError: version.ErrInvalidBump,
+		},
+		"ReturnsErrorWhenNotBumped": {
+			was:           "1.0.0",
+			now:           "1.0.0",
+			expected:      "",
+			expectedError: version.ErrVersionNotBumped,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := version.BumpType(tc.was, tc.now)
+			require.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,436 @@
+package version
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Constraint is a version range in the npm/Cargo style, such as
+// ^1.2 || ~2.0 or >=1.0.0, <2.0.0.
+// A bare version must match exactly, as with npm.
+// The zero Constraint allows every version.
+type Constraint struct {
+	// sets are the alternatives separated by ||. A version satisfies the
+	// constraint when it matches every comparator in any one set.
+	sets [][]comparator
+}
+
+// comparator is a single operator and version that every range is reduced
+// to, e.g. ^1.2.3 becomes >=1.2.3 <2.0.0.
+type comparator struct {
+	op      string
+	version SemVer
+}
+
+// partialVersion is a version where the trailing parts may be missing or a
+// wildcard, e.g. 1.2, 1.x or *.
+type partialVersion struct {
+	major int
+	minor int
+	patch int
+	// parts is the number of parts given as numbers, 0 for * or an empty
+	// version.
+	parts int
+	// prerelease is the pre-release of a full version, e.g. rc.1 in
+	// 1.2.3-rc.1.
+	prerelease string
+}
+
+// constraintOperators are checked in order so the two character operators
+// are matched before their one character prefixes.
+//
+//nolint:gochecknoglobals
+var constraintOperators = []string{">=", "<=", ">", "<", "=", "~", "^"}
+
+// ParseConstraint parses the version range. A full version in the range can
+// have a pre-release, e.g. >=1.2.3-rc.1, and any build metadata is ignored.
+func ParseConstraint(input string) (Constraint, error) {
+	constraint := Constraint{sets: [][]comparator{}}
+
+	for set := range strings.SplitSeq(input, "||") {
+		comparators, err := parseComparatorSet(set)
+		if err != nil {
+			return Constraint{}, fmt.Errorf("%w: %s: %w", ErrInvalidConstraint, input, err)
+		}
+
+		constraint.sets = append(constraint.sets, comparators)
+	}
+
+	return constraint, nil
+}
+
+// Satisfies reports whether the version satisfies the constraint.
+func Satisfies(version string, constraint string) (bool, error) {
+	parsed, err := ParsePrecedence(version)
+	if err != nil {
+		return false, err
+	}
+
+	parsedConstraint, err := ParseConstraint(constraint)
+	if err != nil {
+		return false, err
+	}
+
+	return parsedConstraint.Check(parsed), nil
+}
+
+// Check reports whether the version satisfies the constraint.
+// Like npm, a pre-release only satisfies a range that names a pre-release of
+// the same major.minor.patch version, e.g. 1.2.3-rc.2 satisfies >=1.2.3-rc.1
+// but 2.0.0-rc.1 doesn't satisfy ^1.2 even though it is lower than 2.0.0.
+func (c Constraint) Check(version SemVer) bool {
+	if c.sets == nil {
+		return true
+	}
+
+	for _, set := range c.sets {
+		if matchesAll(set, version) && (version.Prerelease == "" || allowsPrerelease(set, version)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchesAll(comparators []comparator, version SemVer) bool {
+	for _, comp := range comparators {
+		if !comp.matches(version) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// allowsPrerelease reports whether any of the comparators names a pre-release
+// of the same major.minor.patch version as the pre-release version.
+func allowsPrerelease(comparators []comparator, version SemVer) bool {
+	for _, comp := range comparators {
+		if comp.version.Prerelease != "" &&
+			comp.version.Major == version.Major &&
+			comp.version.Minor == version.Minor &&
+			comp.version.Patch == version.Patch {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c comparator) matches(version SemVer) bool {
+	result := version.Cmp(c.version)
+
+	switch c.op {
+	case ">":
+		return result > 0
+	case ">=":
+		return result >= 0
+	case "<":
+		return result < 0
+	case "<=":
+		return result <= 0
+	default:
+		return result == 0
+	}
+}
+
+// parseComparatorSet parses the space or comma separated ranges between ||.
+func parseComparatorSet(set string) ([]comparator, error) {
+	tokens := strings.FieldsFunc(set, func(r rune) bool {
+		return r == ' ' || r == '\t' || r == ','
+	})
+
+	if len(tokens) == 0 {
+		return []comparator{anyVersion()}, nil
+	}
+
+	comparators := []comparator{}
+
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+
+		// Hyphen range, e.g. 1.2.3 - 2.3.4.
+		if i+2 < len(tokens) && tokens[i+1] == "-" {
+			hyphen, err := hyphenRange(token, tokens[i+2])
+			if err != nil {
+				return nil, err
+			}
+
+			comparators = append(comparators, hyphen...)
+			i += 2
+
+			continue
+		}
+
+		// Operator separated from its version, e.g. >= 1.2.3.
+		if isOperator(token) {
+			if i+1 >= len(tokens) {
+				return nil, fmt.Errorf("operator %s has no version", token)
+			}
+
+			i++
+			token += tokens[i]
+		}
+
+		desugared, err := desugar(token)
+		if err != nil {
+			return nil, err
+		}
+
+		comparators = append(comparators, desugared...)
+	}
+
+	return comparators, nil
+}
+
+func isOperator(token string) bool {
+	for _, op := range constraintOperators {
+		if token == op {
+			return true
+		}
+	}
+
+	return false
+}
+
+// desugar reduces a single range, e.g. ~1.2, to the comparators it means.
+func desugar(token string) ([]comparator, error) {
+	op := ""
+
+	for _, candidate := range constraintOperators {
+		if rest, found := strings.CutPrefix(token, candidate); found {
+			op, token = candidate, rest
+
+			break
+		}
+	}
+
+	partial, err := parsePartialVersion(token)
+	if err != nil {
+		return nil, err
+	}
+
+	lower := partial.lower()
+
+	switch op {
+	case ">":
+		if partial.parts == 0 {
+			return []comparator{noVersion()}, nil
+		}
+
+		if partial.parts == semVerParts {
+			return []comparator{{op: ">", version: lower}}, nil
+		}
+
+		return []comparator{{op: ">=", version: partial.upper()}}, nil
+
+	case ">=":
+		return []comparator{{op: ">=", version: lower}}, nil
+
+	case "<":
+		if partial.parts == 0 {
+			return []comparator{noVersion()}, nil
+		}
+
+		return []comparator{{op: "<", version: lower}}, nil
+
+	case "<=":
+		if partial.parts == 0 {
+			return []comparator{anyVersion()}, nil
+		}
+
+		if partial.parts == semVerParts {
+			return []comparator{{op: "<=", version: lower}}, nil
+		}
+
+		return []comparator{{op: "<", version: partial.upper()}}, nil
+
+	case "~":
+		return between(lower, partial.tildeUpper()), nil
+
+	case "^":
+		return between(lower, partial.caretUpper()), nil
+
+	default:
+		switch partial.parts {
+		case 0:
+			return []comparator{anyVersion()}, nil
+		case semVerParts:
+			return []comparator{{op: "=", version: lower}}, nil
+		default:
+			upper := partial.upper()
+
+			return between(lower, &upper), nil
+		}
+	}
+}
+
+// hyphenRange returns the inclusive range between the versions. A partial
+// upper version includes everything it matches, so 1.2 - 2.3 means
+// >=1.2.0 <2.4.0.
+func hyphenRange(from string, to string) ([]comparator, error) {
+	lowerPartial, err := parsePartialVersion(from)
+	if err != nil {
+		return nil, err
+	}
+
+	upperPartial, err := parsePartialVersion(to)
+	if err != nil {
+		return nil, err
+	}
+
+	comparators := []comparator{{op: ">=", version: lowerPartial.lower()}}
+
+	switch upperPartial.parts {
+	case 0:
+		return comparators, nil
+	case semVerParts:
+		return append(comparators, comparator{op: "<=", version: upperPartial.lower()}), nil
+	default:
+		return append(comparators, comparator{op: "<", version: upperPartial.upper()}), nil
+	}
+}
+
+// between returns the comparators for versions from lower up to, but not
+// including, upper. A nil upper has no upper bound.
+func between(lower SemVer, upper *SemVer) []comparator {
+	comparators := []comparator{{op: ">=", version: lower}}
+
+	if upper != nil {
+		comparators = append(comparators, comparator{op: "<", version: *upper})
+	}
+
+	return comparators
+}
+
+func anyVersion() comparator {
+	return comparator{op: ">=", version: SemVer{}}
+}
+
+func noVersion() comparator {
+	return comparator{op: "<", version: SemVer{}}
+}
+
+// parsePartialVersion parses a version that may be missing trailing parts or
+// use x, X or * as a wildcard. Only a full version can have a pre-release.
+func parsePartialVersion(input string) (partialVersion, error) {
+	input, _, _ = strings.Cut(strings.TrimPrefix(input, prefix), "+")
+	input, prerelease, hasPrerelease := strings.Cut(input, "-")
+	partial := partialVersion{prerelease: prerelease}
+
+	if hasPrerelease {
+		if err := validateIdentifiers(prerelease, "pre-release", true); err != nil {
+			return partialVersion{}, err
+		}
+	}
+
+	if input == "" {
+		return partial, nil
+	}
+
+	parts := strings.Split(input, ".")
+	if len(parts) > semVerParts {
+		return partialVersion{}, fmt.Errorf("%w: %s", ErrNumVersionParts, input)
+	}
+
+	values := []*int{&partial.major, &partial.minor, &partial.patch}
+	wildcard := false
+
+	for i, part := range parts {
+		if part == "x" || part == "X" || part == "*" {
+			wildcard = true
+
+			continue
+		}
+
+		if wildcard {
+			return partialVersion{}, fmt.Errorf("version part %s follows a wildcard", part)
+		}
+
+		num, err := strconv.Atoi(part)
+		if err != nil || num < 0 {
+			return partialVersion{}, fmt.Errorf("%w: %s", ErrConvertingToInt, part)
+		}
+
+		*values[i] = num
+		partial.parts++
+	}
+
+	if hasPrerelease && partial.parts != semVerParts {
+		return partialVersion{}, fmt.Errorf("pre-release %s needs a full version", prerelease)
+	}
+
+	return partial, nil
+}
+
+// lower is the lowest version the partial version matches.
+func (p partialVersion) lower() SemVer {
+	lower := p.release()
+	lower.Prerelease = p.prerelease
+
+	return lower
+}
+
+// release is the version without any pre-release, as the ranges built on a
+// pre-release end before the next release, e.g. ^1.2.3-rc.1 means
+// >=1.2.3-rc.1 <2.0.0.
+func (p partialVersion) release() SemVer {
+	return SemVer{Major: p.major, Minor: p.minor, Patch: p.patch, Prefix: ""}
+}
+
+// upper is the lowest version above the ones the partial version matches,
+// e.g. 2.0.0 for 1.x. A full version has the next patch as its upper.
+func (p partialVersion) upper() SemVer {
+	next := p.release()
+
+	switch p.parts {
+	case 1:
+		next.MajorBump()
+	case semVerParts - 1:
+		next.MinorBump()
+	default:
+		next.PatchBump()
+	}
+
+	return next
+}
+
+// tildeUpper allows patch changes when the minor version is given, and minor
+// changes when it isn't.
+func (p partialVersion) tildeUpper() *SemVer {
+	if p.parts == 0 {
+		return nil
+	}
+
+	next := p.release()
+
+	if p.parts == 1 {
+		next.MajorBump()
+	} else {
+		next.MinorBump()
+	}
+
+	return &next
+}
+
+// caretUpper allows changes that don't modify the left most non-zero part, so
+// ^1.2.3 allows minor and patch changes but ^0.2.3 only allows patch changes.
+func (p partialVersion) caretUpper() *SemVer {
+	if p.parts == 0 {
+		return nil
+	}
+
+	next := p.release()
+
+	switch {
+	case p.major > 0 || p.parts == 1:
+		next.MajorBump()
+	case p.minor > 0 || p.parts == semVerParts-1:
+		next.MinorBump()
+	default:
+		next.PatchBump()
+	}
+
+	return &next
+}
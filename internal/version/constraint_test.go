@@ -0,0 +1,186 @@
+package version_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tx3stn/vrsn/internal/version"
+)
+
+func TestSatisfies(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		constraint string
+		matching   []string
+		excluded   []string
+	}{
+		"ExactVersion": {
+			constraint: "1.2.3",
+			matching:   []string{"1.2.3", "v1.2.3"},
+			excluded:   []string{"1.2.4", "1.2.2"},
+		},
+		"Comparison": {
+			constraint: ">=1.2.0 <2.0.0",
+			matching:   []string{"1.2.0", "1.9.9"},
+			excluded:   []string{"1.1.9", "2.0.0"},
+		},
+		"CommaSeparatedComparison": {
+			constraint: ">= 1.2.0, < 2.0.0",
+			matching:   []string{"1.2.0", "1.9.9"},
+			excluded:   []string{"1.1.9", "2.0.0"},
+		},
+		"GreaterThanPartial": {
+			constraint: ">1.2",
+			matching:   []string{"1.3.0"},
+			excluded:   []string{"1.2.9"},
+		},
+		"LessThanOrEqualPartial": {
+			constraint: "<=1.2",
+			matching:   []string{"1.2.9"},
+			excluded:   []string{"1.3.0"},
+		},
+		"Tilde": {
+			constraint: "~1.2.3",
+			matching:   []string{"1.2.3", "1.2.9"},
+			excluded:   []string{"1.2.2", "1.3.0"},
+		},
+		"TildeMajorOnly": {
+			constraint: "~1",
+			matching:   []string{"1.0.0", "1.9.0"},
+			excluded:   []string{"2.0.0"},
+		},
+		"Caret": {
+			constraint: "^1.2.3",
+			matching:   []string{"1.2.3", "1.9.0"},
+			excluded:   []string{"1.2.2", "2.0.0"},
+		},
+		"CaretPartial": {
+			constraint: "^1.2",
+			matching:   []string{"1.2.0", "1.9.0"},
+			excluded:   []string{"1.1.9", "2.0.0"},
+		},
+		"CaretZeroMajor": {
+			constraint: "^0.2.3",
+			matching:   []string{"0.2.3", "0.2.9"},
+			excluded:   []string{"0.3.0"},
+		},
+		"CaretZeroMajorAndMinor": {
+			constraint: "^0.0.3",
+			matching:   []string{"0.0.3"},
+			excluded:   []string{"0.0.4", "0.1.0"},
+		},
+		"CaretZeroMajorPartial": {
+			constraint: "^0.0",
+			matching:   []string{"0.0.9"},
+			excluded:   []string{"0.1.0"},
+		},
+		"XRange": {
+			constraint: "1.x",
+			matching:   []string{"1.0.0", "1.9.9"},
+			excluded:   []string{"0.9.9", "2.0.0"},
+		},
+		"XRangeMinor": {
+			constraint: "1.2.*",
+			matching:   []string{"1.2.0", "1.2.9"},
+			excluded:   []string{"1.3.0"},
+		},
+		"Any": {
+			constraint: "*",
+			matching:   []string{"0.0.0", "9.9.9"},
+			excluded:   nil,
+		},
+		"HyphenRange": {
+			constraint: "1.2.3 - 2.3.4",
+			matching:   []string{"1.2.3", "2.3.4"},
+			excluded:   []string{"1.2.2", "2.3.5"},
+		},
+		"HyphenRangePartialUpper": {
+			constraint: "1.2 - 2.3",
+			matching:   []string{"1.2.0", "2.3.9"},
+			excluded:   []string{"1.1.9", "2.4.0"},
+		},
+		"Alternatives": {
+			constraint: "^1.2 || ~2.0",
+			matching:   []string{"1.4.2", "2.0.5"},
+			excluded:   []string{"1.1.0", "2.1.0"},
+		},
+		"Prereleases": {
+			constraint: "^1.2",
+			matching:   []string{"1.4.2+build.7"},
+			excluded:   []string{"1.4.2-rc.1", "2.0.0-rc.1"},
+		},
+		"PrereleaseComparator": {
+			constraint: ">=1.2.3-rc.1",
+			matching:   []string{"1.2.3-rc.1", "1.2.3-rc.2", "1.2.3", "2.0.0"},
+			excluded:   []string{"1.2.3-beta.1", "1.2.4-rc.1", "1.2.2"},
+		},
+		"CaretPrerelease": {
+			constraint: "^1.2.3-beta.2+build.1",
+			matching:   []string{"1.2.3-beta.2", "1.2.3-beta.10", "1.2.3", "1.9.0"},
+			excluded:   []string{"1.2.3-beta.1", "1.3.0-beta.1", "2.0.0"},
+		},
+		"ExactPrerelease": {
+			constraint: "1.2.3-rc.1",
+			matching:   []string{"1.2.3-rc.1"},
+			excluded:   []string{"1.2.3-rc.2", "1.2.3"},
+		},
+		"HyphenRangePrerelease": {
+			constraint: "1.2.3-rc.1 - 2.0.0",
+			matching:   []string{"1.2.3-rc.1", "2.0.0"},
+			excluded:   []string{"1.2.3-alpha", "2.0.0-rc.1"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			for _, v := range tc.matching {
+				actual, err := version.Satisfies(v, tc.constraint)
+				require.NoError(t, err)
+				assert.True(t, actual, "%s should satisfy %s", v, tc.constraint)
+			}
+
+			for _, v := range tc.excluded {
+				actual, err := version.Satisfies(v, tc.constraint)
+				require.NoError(t, err)
+				assert.False(t, actual, "%s should not satisfy %s", v, tc.constraint)
+			}
+		})
+	}
+}
+
+func TestParseConstraintErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]string{
+		"NonNumericPart":        "^1.a",
+		"TooManyParts":          "1.2.3.4",
+		"OperatorWithNoVersion": ">=",
+		"NumberAfterWildcard":   "1.x.3",
+		"PartialPrerelease":     "^1.2-rc.1",
+		"InvalidPrerelease":     ">=1.2.3-rc..1",
+	}
+
+	for name, constraint := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := version.ParseConstraint(constraint)
+			require.ErrorIs(t, err, version.ErrInvalidConstraint)
+		})
+	}
+}
+
+func TestZeroConstraintAllowsEveryVersion(t *testing.T) {
+	t.Parallel()
+
+	var constraint version.Constraint
+
+	v2, err := version.ParsePrecedence("2.0.0-rc.1")
+	require.NoError(t, err)
+
+	assert.True(t, constraint.Check(v2))
+}
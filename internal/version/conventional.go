@@ -0,0 +1,49 @@
+package version
+
+import (
+	"regexp"
+	"strings"
+)
+
+// conventionalHeaderRegex matches a Conventional Commits header, capturing the
+// type and the optional ! marking a breaking change, e.g. feat(api)!: ...
+var conventionalHeaderRegex = regexp.MustCompile(`^(\w+)(?:\([^)]*\))?(!)?: `)
+
+// IncrementFromCommits returns the increment type the commit messages call for
+// following Conventional Commits: major for any breaking change, minor for
+// any feat commit and patch for everything else, including no commits.
+func IncrementFromCommits(messages []string) string {
+	increment := "patch"
+
+	for _, message := range messages {
+		header, body, _ := strings.Cut(message, "\n")
+
+		if isBreakingChange(header, body) {
+			return "major"
+		}
+
+		matches := conventionalHeaderRegex.FindStringSubmatch(header)
+		if matches != nil && strings.EqualFold(matches[1], "feat") {
+			increment = "minor"
+		}
+	}
+
+	return increment
+}
+
+// isBreakingChange reports whether the commit is marked as breaking, either
+// with a ! in the header or a BREAKING CHANGE footer.
+func isBreakingChange(header string, body string) bool {
+	matches := conventionalHeaderRegex.FindStringSubmatch(header)
+	if matches != nil && matches[2] == "!" {
+		return true
+	}
+
+	for line := range strings.SplitSeq(body, "\n") {
+		if strings.HasPrefix(line, "BREAKING CHANGE:") || strings.HasPrefix(line, "BREAKING-CHANGE:") {
+			return true
+		}
+	}
+
+	return false
+}
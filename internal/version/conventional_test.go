@@ -0,0 +1,50 @@
+package version_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tx3stn/vrsn/internal/version"
+)
+
+func TestIncrementFromCommits(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		messages []string
+		expected string
+	}{
+		"ReturnsPatchForNoCommits": {
+			messages: nil,
+			expected: "patch",
+		},
+		"ReturnsPatchForFixesAndNonConventionalCommits": {
+			messages: []string{"fix: handle empty file", "update readme"},
+			expected: "patch",
+		},
+		"ReturnsMinorForFeat": {
+			messages: []string{"fix: handle empty file", "feat(cli): add next command"},
+			expected: "minor",
+		},
+		"ReturnsMajorForBangInHeader": {
+			messages: []string{"feat: add next command", "refactor(api)!: rename options"},
+			expected: "major",
+		},
+		"ReturnsMajorForBreakingChangeFooter": {
+			messages: []string{"fix: drop flag\n\nBREAKING CHANGE: --old is removed"},
+			expected: "major",
+		},
+		"IgnoresBreakingChangeInHeader": {
+			messages: []string{"docs: explain BREAKING CHANGE: footers"},
+			expected: "patch",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, version.IncrementFromCommits(tc.messages))
+		})
+	}
+}
@@ -1,5 +1,7 @@
 package version
 
+import "strconv"
+
 // Error is the error type.
 type Error uint
 
@@ -20,6 +22,18 @@ const (
 	ErrVersionNotBumped
 	// ErrInvalidIncrementType is the error when the selected increment type is incorrect.
 	ErrInvalidIncrementType
+	// ErrInvalidConstraint is the error when a version constraint can't be
+	// parsed.
+	ErrInvalidConstraint
+	// ErrConstraintNotSatisfied is the error when a version doesn't satisfy a
+	// version constraint.
+	ErrConstraintNotSatisfied
+	// ErrVersionPartTooLarge is the error when the minor or patch version
+	// doesn't fit in the digits the build number formula reserves for it.
+	ErrVersionPartTooLarge
+	// ErrInvalidIdentifier is the error when the pre-release or build metadata
+	// of a version isn't made up of valid identifiers.
+	ErrInvalidIdentifier
 )
 
 // Error returns the error string for the error enum.
@@ -43,7 +57,25 @@ func (e Error) Error() string {
 	case ErrInvalidIncrementType:
 		return "invalid increment type"
 
+	case ErrInvalidConstraint:
+		return "invalid version constraint"
+
+	case ErrConstraintNotSatisfied:
+		return "version does not satisfy constraint"
+
+	case ErrVersionPartTooLarge:
+		return "version part is too large for the digits reserved for it in the build number"
+
+	case ErrInvalidIdentifier:
+		return "pre-release and build metadata must be dot separated identifiers of letters, digits and hyphens"
+
 	default:
 		return "unknown error"
 	}
 }
+
+// Code returns the stable code for the error enum used in machine readable
+// output.
+func (e Error) Code() string {
+	return "version." + strconv.FormatUint(uint64(e), 10)
+}
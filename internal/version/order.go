@@ -0,0 +1,115 @@
+package version
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Cmp compares the precedence of the versions, returning -1 if s is lower
+// than other, 0 if they are equal and +1 if s is higher. The v prefix and
+// build metadata are ignored, so v1.2.3 and 1.2.3+build.4 are equal, and a
+// pre-release is lower than the version it precedes, see comparePrerelease.
+func (s *SemVer) Cmp(other SemVer) int {
+	if c := cmp.Compare(s.Major, other.Major); c != 0 {
+		return c
+	}
+
+	if c := cmp.Compare(s.Minor, other.Minor); c != 0 {
+		return c
+	}
+
+	if c := cmp.Compare(s.Patch, other.Patch); c != 0 {
+		return c
+	}
+
+	return comparePrerelease(s.Prerelease, other.Prerelease)
+}
+
+// comparePrerelease compares the pre-releases of versions with the same
+// major, minor and patch version following the semantic versioning spec:
+// no pre-release is higher than any, otherwise the identifiers are compared
+// in turn, numerically when both are numbers, with numbers lower than other
+// identifiers, and the pre-release with more identifiers is higher when all of
+// the others match, e.g. 1.0.0-alpha < 1.0.0-alpha.1 < 1.0.0-beta < 1.0.0.
+func comparePrerelease(a string, b string) int {
+	switch {
+	case a == b:
+		return 0
+
+	case a == "":
+		return 1
+
+	case b == "":
+		return -1
+	}
+
+	aIdentifiers := strings.Split(a, ".")
+	bIdentifiers := strings.Split(b, ".")
+
+	for i := range min(len(aIdentifiers), len(bIdentifiers)) {
+		if c := compareIdentifier(aIdentifiers[i], bIdentifiers[i]); c != 0 {
+			return c
+		}
+	}
+
+	return cmp.Compare(len(aIdentifiers), len(bIdentifiers))
+}
+
+// compareIdentifier compares a single pre-release identifier.
+func compareIdentifier(a string, b string) int {
+	aNumeric, bNumeric := isNumeric(a), isNumeric(b)
+
+	switch {
+	case aNumeric && bNumeric:
+		// Numeric identifiers have no leading zeros, so the longer one is the
+		// higher number, without parsing numbers that could overflow an int.
+		if c := cmp.Compare(len(a), len(b)); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a, b)
+
+	case aNumeric:
+		return -1
+
+	case bNumeric:
+		return 1
+
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+// Sort returns the versions sorted from lowest to highest precedence, with
+// pre-releases before the version they precede. Versions with the same
+// precedence, such as 1.2.3 and v1.2.3, keep their input order.
+func Sort(versions []string) ([]string, error) {
+	parsed := make([]SemVer, 0, len(versions))
+
+	for _, version := range versions {
+		semVer, err := ParsePrecedence(version)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing %s: %w", version, err)
+		}
+
+		parsed = append(parsed, semVer)
+	}
+
+	indexes := make([]int, len(versions))
+	for i := range indexes {
+		indexes[i] = i
+	}
+
+	slices.SortStableFunc(indexes, func(a int, b int) int {
+		return parsed[a].Cmp(parsed[b])
+	})
+
+	sorted := make([]string, 0, len(versions))
+	for _, i := range indexes {
+		sorted = append(sorted, versions[i])
+	}
+
+	return sorted, nil
+}
@@ -0,0 +1,141 @@
+package version_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tx3stn/vrsn/internal/version"
+)
+
+func TestCmp(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		a        string
+		b        string
+		expected int
+	}{
+		"ReturnsZeroForEqualVersions": {
+			a:        "1.2.3",
+			b:        "1.2.3",
+			expected: 0,
+		},
+		"IgnoresThePrefix": {
+			a:        "v1.2.3",
+			b:        "1.2.3",
+			expected: 0,
+		},
+		"ComparesMajorFirst": {
+			a:        "2.0.0",
+			b:        "1.9.9",
+			expected: 1,
+		},
+		"ComparesMinorBeforePatch": {
+			a:        "1.2.9",
+			b:        "1.3.0",
+			expected: -1,
+		},
+		"ComparesNumerically": {
+			a:        "0.0.10",
+			b:        "0.0.9",
+			expected: 1,
+		},
+		"RanksPrereleaseBelowRelease": {
+			a:        "1.0.0-alpha",
+			b:        "1.0.0",
+			expected: -1,
+		},
+		"RanksPrereleaseAboveEarlierRelease": {
+			a:        "1.0.0-alpha",
+			b:        "0.9.9",
+			expected: 1,
+		},
+		"ComparesPrereleaseIdentifiersLexically": {
+			a:        "1.0.0-beta",
+			b:        "1.0.0-alpha.1",
+			expected: 1,
+		},
+		"ComparesNumericPrereleaseIdentifiersNumerically": {
+			a:        "1.0.0-rc.10",
+			b:        "1.0.0-rc.9",
+			expected: 1,
+		},
+		"RanksNumericPrereleaseIdentifiersBelowOthers": {
+			a:        "1.0.0-1",
+			b:        "1.0.0-alpha",
+			expected: -1,
+		},
+		"RanksLongerPrereleaseHigher": {
+			a:        "1.0.0-alpha",
+			b:        "1.0.0-alpha.1",
+			expected: -1,
+		},
+		"IgnoresBuildMetadata": {
+			a:        "1.0.0+build.2",
+			b:        "1.0.0+build.1",
+			expected: 0,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			a, err := version.ParsePrecedence(tc.a)
+			require.NoError(t, err)
+
+			b, err := version.ParsePrecedence(tc.b)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, a.Cmp(b))
+		})
+	}
+}
+
+func TestSort(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		versions      []string
+		expected      []string
+		expectedError error
+	}{
+		"SortsByPrecedence": {
+			versions:      []string{"1.10.0", "0.0.10", "v1.2.0", "0.0.9"},
+			expected:      []string{"0.0.9", "0.0.10", "v1.2.0", "1.10.0"},
+			expectedError: nil,
+		},
+		"KeepsInputOrderForEqualVersions": {
+			versions:      []string{"v1.0.0", "0.1.0", "1.0.0"},
+			expected:      []string{"0.1.0", "v1.0.0", "1.0.0"},
+			expectedError: nil,
+		},
+		"SortsPrereleasesBySemVerPrecedence": {
+			versions: []string{
+				"1.0.0", "1.0.0-rc.1", "1.0.0-beta.11", "1.0.0-beta", "1.0.0-alpha.beta",
+				"1.0.0-beta.2", "1.0.0-alpha.1", "1.0.0-alpha",
+			},
+			expected: []string{
+				"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta",
+				"1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0",
+			},
+			expectedError: nil,
+		},
+		"ReturnsErrorForInvalidVersion": {
+			versions:      []string{"1.0.0", "latest"},
+			expected:      nil,
+			expectedError: version.ErrNoVersionParts,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := version.Sort(tc.versions)
+			require.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
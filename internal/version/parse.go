@@ -13,11 +13,20 @@ type SemVer struct {
 	Minor  int
 	Patch  int
 	Prefix string
+	// Prerelease is the pre-release after the -, e.g. rc.1 in 1.2.3-rc.1, only
+	// set by ParsePrecedence.
+	Prerelease string
+	// Build is the build metadata after the +, e.g. build.4 in 1.2.3+build.4,
+	// only set by ParsePrecedence. It is ignored when comparing versions.
+	Build string
 }
 
 const (
 	prefix      = "v"
 	semVerParts = 3
+	// identifierChars are the characters allowed in pre-release and build
+	// metadata identifiers.
+	identifierChars = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz-"
 )
 
 // Parse checks the input string is a valid semantic version and
@@ -62,6 +71,60 @@ func Parse(version string) (SemVer, error) {
 	}, nil
 }
 
+// ParsePrecedence parses the version like Parse, also accepting the
+// pre-release and build metadata of a full semantic version, e.g.
+// 1.2.3-rc.1+build.4, for ordering versions. Writing versions still needs a
+// plain major.minor.patch version, parsed with Parse.
+func ParsePrecedence(version string) (SemVer, error) {
+	// Build metadata can contain hyphens, so it is split off first.
+	version, build, hasBuild := strings.Cut(version, "+")
+	core, prerelease, hasPrerelease := strings.Cut(version, "-")
+
+	if hasPrerelease {
+		if err := validateIdentifiers(prerelease, "pre-release", true); err != nil {
+			return SemVer{}, err
+		}
+	}
+
+	if hasBuild {
+		if err := validateIdentifiers(build, "build metadata", false); err != nil {
+			return SemVer{}, err
+		}
+	}
+
+	parsed, err := Parse(core)
+	if err != nil {
+		return SemVer{}, err
+	}
+
+	parsed.Prerelease = prerelease
+	parsed.Build = build
+
+	return parsed, nil
+}
+
+// validateIdentifiers checks the pre-release or build metadata is made up of
+// dot separated identifiers of letters, digits and hyphens. Numeric
+// pre-release identifiers can't have leading zeros.
+func validateIdentifiers(identifiers string, name string, numeric bool) error {
+	for identifier := range strings.SplitSeq(identifiers, ".") {
+		if identifier == "" || strings.Trim(identifier, identifierChars) != "" {
+			return fmt.Errorf("%w: %s %s", ErrInvalidIdentifier, name, identifiers)
+		}
+
+		if numeric && isNumeric(identifier) && len(identifier) > 1 && identifier[0] == '0' {
+			return fmt.Errorf("%w: %s %s has a leading zero", ErrInvalidIdentifier, name, identifiers)
+		}
+	}
+
+	return nil
+}
+
+// isNumeric reports whether the identifier is only digits.
+func isNumeric(identifier string) bool {
+	return strings.Trim(identifier, "0123456789") == ""
+}
+
 // parsePart converts a single version part to an int, rejecting anything
 // that isn't a non-negative number.
 func parsePart(part string, name string) (int, error) {
@@ -97,18 +160,45 @@ func (s *SemVer) PatchBump() {
 
 // String returns the string representation of a SemVer struct.
 func (s *SemVer) String() string {
-	return fmt.Sprintf("%s%d.%d.%d", s.Prefix, s.Major, s.Minor, s.Patch)
+	version := fmt.Sprintf("%s%d.%d.%d", s.Prefix, s.Major, s.Minor, s.Patch)
+
+	if s.Prerelease != "" {
+		version += "-" + s.Prerelease
+	}
+
+	if s.Build != "" {
+		version += "+" + s.Build
+	}
+
+	return version
 }
 
-const (
-	androidMajorMultiplier = 10000
-	androidMinorMultiplier = 100
-)
+// BuildNumber derives an integer build number, such as an Android
+// versionCode, from the semantic version as MAJOR, then MINOR padded to
+// minorDigits, then PATCH padded to patchDigits, e.g. 1.2.3 is 10203 with two
+// digits each. ErrVersionPartTooLarge is returned if the minor or patch version
+// needs more digits than reserved for it, rather than colliding with the
+// build number of another version.
+func (s *SemVer) BuildNumber(minorDigits int, patchDigits int) (int, error) {
+	minorLimit := pow10(minorDigits)
+	patchLimit := pow10(patchDigits)
+
+	if s.Minor >= minorLimit || s.Patch >= patchLimit {
+		return 0, fmt.Errorf(
+			"%w: %d.%d.%d with %d minor and %d patch digits",
+			ErrVersionPartTooLarge, s.Major, s.Minor, s.Patch, minorDigits, patchDigits,
+		)
+	}
+
+	return (s.Major*minorLimit+s.Minor)*patchLimit + s.Patch, nil
+}
+
+// pow10 returns 10 to the power of n.
+func pow10(n int) int {
+	result := 1
+	for range n {
+		result *= 10
+	}
 
-// AndroidVersionCode derives an Android versionCode integer from the semantic
-// version using the conventional MAJOR*10000 + MINOR*100 + PATCH scheme. This
-// reserves two digits each for the minor and patch parts, so it assumes both
-// are below 100.
-func (s *SemVer) AndroidVersionCode() int {
-	return s.Major*androidMajorMultiplier + s.Minor*androidMinorMultiplier + s.Patch
+	return result
 }
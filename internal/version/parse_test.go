@@ -85,6 +85,11 @@ func TestParse(t *testing.T) {
 			expectedError: version.ErrConvertingToInt,
 			expected:      version.SemVer{},
 		},
+		"ReturnsErrorForPrerelease": {
+			input:         "1.2.3-rc1",
+			expectedError: version.ErrConvertingToInt,
+			expected:      version.SemVer{},
+		},
 	}
 
 	for name, testCase := range testCases {
@@ -100,28 +105,145 @@ func TestParse(t *testing.T) {
 	}
 }
 
-func TestAndroidVersionCode(t *testing.T) {
+func TestParsePrecedence(t *testing.T) {
 	t.Parallel()
 
 	testCases := map[string]struct {
-		input    version.SemVer
-		expected int
+		input         string
+		expectedError error
+		expected      version.SemVer
+	}{
+		"ReturnsVersionStructForPlainVersion": {
+			input:         "v1.2.3",
+			expectedError: nil,
+			expected:      version.SemVer{Major: 1, Minor: 2, Patch: 3, Prefix: "v"},
+		},
+		"ReturnsPrerelease": {
+			input:         "1.2.3-rc.1",
+			expectedError: nil,
+			expected:      version.SemVer{Major: 1, Minor: 2, Patch: 3, Prerelease: "rc.1"},
+		},
+		"ReturnsBuildMetadata": {
+			input:         "1.2.3+build.4",
+			expectedError: nil,
+			expected:      version.SemVer{Major: 1, Minor: 2, Patch: 3, Build: "build.4"},
+		},
+		"ReturnsPrereleaseAndBuildMetadataWithHyphens": {
+			input:         "1.2.3-x-y.7+exp-sha.5114f85",
+			expectedError: nil,
+			expected: version.SemVer{
+				Major:      1,
+				Minor:      2,
+				Patch:      3,
+				Prerelease: "x-y.7",
+				Build:      "exp-sha.5114f85",
+			},
+		},
+		"ReturnsErrorForEmptyPrereleaseIdentifier": {
+			input:         "1.2.3-rc..1",
+			expectedError: version.ErrInvalidIdentifier,
+			expected:      version.SemVer{},
+		},
+		"ReturnsErrorForEmptyPrerelease": {
+			input:         "1.2.3-",
+			expectedError: version.ErrInvalidIdentifier,
+			expected:      version.SemVer{},
+		},
+		"ReturnsErrorForLeadingZeroInNumericPrerelease": {
+			input:         "1.2.3-rc.01",
+			expectedError: version.ErrInvalidIdentifier,
+			expected:      version.SemVer{},
+		},
+		"AllowsLeadingZeroInBuildMetadata": {
+			input:         "1.2.3+001",
+			expectedError: nil,
+			expected:      version.SemVer{Major: 1, Minor: 2, Patch: 3, Build: "001"},
+		},
+		"ReturnsErrorForInvalidBuildMetadataCharacters": {
+			input:         "1.2.3+build_4",
+			expectedError: version.ErrInvalidIdentifier,
+			expected:      version.SemVer{},
+		},
+		"ReturnsErrorForInvalidCore": {
+			input:         "1.2-rc.1",
+			expectedError: version.ErrNumVersionParts,
+			expected:      version.SemVer{},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := version.ParsePrecedence(tc.input)
+			require.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, tc.expected, actual)
+
+			if err == nil {
+				assert.Equal(t, tc.input, actual.String())
+			}
+		})
+	}
+}
+
+func TestBuildNumber(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		input         version.SemVer
+		minorDigits   int
+		patchDigits   int
+		expected      int
+		expectedError error
 	}{
 		"CombinesEachPart": {
-			input:    version.SemVer{Major: 1, Minor: 2, Patch: 3},
-			expected: 10203,
+			input:         version.SemVer{Major: 1, Minor: 2, Patch: 3},
+			minorDigits:   2,
+			patchDigits:   2,
+			expected:      10203,
+			expectedError: nil,
 		},
 		"PadsSingleDigitParts": {
-			input:    version.SemVer{Major: 0, Minor: 0, Patch: 1},
-			expected: 1,
+			input:         version.SemVer{Major: 0, Minor: 0, Patch: 1},
+			minorDigits:   2,
+			patchDigits:   2,
+			expected:      1,
+			expectedError: nil,
 		},
 		"HandlesMajorOnly": {
-			input:    version.SemVer{Major: 12, Minor: 0, Patch: 0},
-			expected: 120000,
+			input:         version.SemVer{Major: 12, Minor: 0, Patch: 0},
+			minorDigits:   2,
+			patchDigits:   2,
+			expected:      120000,
+			expectedError: nil,
 		},
 		"IgnoresPrefix": {
-			input:    version.SemVer{Major: 2, Minor: 14, Patch: 74, Prefix: "v"},
-			expected: 21474,
+			input:         version.SemVer{Major: 2, Minor: 14, Patch: 74, Prefix: "v"},
+			minorDigits:   2,
+			patchDigits:   2,
+			expected:      21474,
+			expectedError: nil,
+		},
+		"UsesConfiguredDigits": {
+			input:         version.SemVer{Major: 1, Minor: 2, Patch: 345},
+			minorDigits:   3,
+			patchDigits:   3,
+			expected:      1002345,
+			expectedError: nil,
+		},
+		"ErrorsWhenPatchOverflows": {
+			input:         version.SemVer{Major: 1, Minor: 2, Patch: 100},
+			minorDigits:   2,
+			patchDigits:   2,
+			expected:      0,
+			expectedError: version.ErrVersionPartTooLarge,
+		},
+		"ErrorsWhenMinorOverflows": {
+			input:         version.SemVer{Major: 1, Minor: 100, Patch: 0},
+			minorDigits:   2,
+			patchDigits:   2,
+			expected:      0,
+			expectedError: version.ErrVersionPartTooLarge,
 		},
 	}
 
@@ -131,7 +253,9 @@ func TestAndroidVersionCode(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 
-			assert.Equal(t, tc.expected, tc.input.AndroidVersionCode())
+			actual, err := tc.input.BuildNumber(tc.minorDigits, tc.patchDigits)
+			require.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, tc.expected, actual)
 		})
 	}
 }
@@ -0,0 +1,285 @@
+package vrsn
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/tx3stn/vrsn/internal/files"
+	"github.com/tx3stn/vrsn/internal/git"
+	"github.com/tx3stn/vrsn/internal/logger"
+	"github.com/tx3stn/vrsn/internal/version"
+)
+
+type (
+	// BranchPolicy restricts the version changes Check accepts on the
+	// branches matching the pattern it is keyed by in WithBranchPolicies.
+	BranchPolicy struct {
+		// Bumps are the allowed bump types, any bump type is allowed when empty.
+		Bumps []string `toml:"bumps"`
+		// Range is the version range the new version must be in.
+		Range string `toml:"range"`
+	}
+
+	// CheckResult is what Check found, filled in as far as the check got when
+	// it returns an error.
+	CheckResult struct {
+		// Branch is the current git branch, empty when it couldn't be read.
+		Branch string
+		// BumpType is the Patch, Minor or Major increment from Was to Now.
+		BumpType string
+		// Files are the version files the versions were read from, empty when
+		// both versions were set with WithWas and WithNow.
+		Files []string
+		// Now is the new version.
+		Now string
+		// Policy is the pattern of the branch policy that applied to Branch.
+		Policy string
+		// Range is the version range Now was checked against, combining
+		// WithRange and WithMaxVersion.
+		Range string
+		// Satisfies is whether Now is in Range, only set when Range is.
+		Satisfies bool
+		// Was is the previous version.
+		Was string
+	}
+)
+
+// Check checks the version has been validly bumped: the change from the
+// previous version, read from the version files on the base branch, to the
+// new version in the version files, must be a single semantic version
+// increment. The new version must also be in any version range and be
+// allowed by the branch policy for the current branch.
+// WithWas and WithNow set the versions instead of reading them from files.
+func Check(ctx context.Context, opts ...Option) (CheckResult, error) {
+	o := newOptions(opts)
+	result := CheckResult{}
+	ctx = logger.NewContext(ctx, o.logger)
+
+	// The branch is only required to read the previous version, otherwise
+	// the check can run outside of a git repo, with no branch policy applied.
+	branch, branchErr := git.CurrentBranch(ctx, o.dir)
+	if branchErr == nil {
+		result.Branch = branch
+	}
+
+	if o.was == "" || o.now == "" {
+		versionFiles, err := o.versionFiles()
+		if err != nil {
+			return result, err
+		}
+
+		result.Files = versionFiles
+	}
+
+	now, err := o.nowVersion(ctx, result.Files)
+	if err != nil {
+		return result, err
+	}
+
+	result.Now = now
+
+	if o.was == "" && branchErr != nil {
+		return result, fmt.Errorf("error getting current git branch: %w", branchErr)
+	}
+
+	was, err := o.wasVersion(ctx, branch, result.Files)
+	if err != nil {
+		return result, err
+	}
+
+	result.Was = was
+
+	bumpType, err := version.BumpType(was, now)
+	if err != nil {
+		return result, fmt.Errorf("error comparing versions: %w", err)
+	}
+
+	result.BumpType = bumpType
+
+	if err := o.checkRange(&result); err != nil {
+		return result, err
+	}
+
+	return result, o.checkBranchPolicy(&result)
+}
+
+// PolicyForBranch returns the branch policy that applies to the branch, along
+// with the pattern it is keyed by. Patterns use path.Match syntax so hotfix/*
+// matches hotfix/login but not hotfix/login/part-2. When several patterns
+// match, the longest, and so most specific, one is used.
+func PolicyForBranch(policies map[string]BranchPolicy, branch string) (string, BranchPolicy, bool) {
+	matched := ""
+	found := false
+
+	for _, pattern := range slices.Sorted(maps.Keys(policies)) {
+		// A malformed pattern can never match, so its error is ignored.
+		if ok, _ := path.Match(pattern, branch); !ok {
+			continue
+		}
+
+		if !found || len(pattern) > len(matched) {
+			matched = pattern
+			found = true
+		}
+	}
+
+	return matched, policies[matched], found
+}
+
+// nowVersion returns the version set with WithNow, falling back to the
+// version in the version files.
+func (o options) nowVersion(ctx context.Context, versionFiles []string) (string, error) {
+	if o.now != "" {
+		return o.now, nil
+	}
+
+	if err := ctx.Err(); err != nil {
+		return "", fmt.Errorf("error reading version from files: %w", err)
+	}
+
+	now, err := files.GetVersionsFromFiles(o.dir, versionFiles, o.anchors, o.debugLogger())
+	if err != nil {
+		return "", fmt.Errorf("error reading version from files: %w", err)
+	}
+
+	return now, nil
+}
+
+// wasVersion returns the version set with WithWas, falling back to the common
+// version the version files contained on the base branch.
+func (o options) wasVersion(
+	ctx context.Context,
+	branch string,
+	versionFiles []string,
+) (string, error) {
+	if o.was != "" {
+		return o.was, nil
+	}
+
+	if branch == o.baseBranch {
+		return "", fmt.Errorf("%w: base branch: %s", ErrOnBaseBranch, o.baseBranch)
+	}
+
+	versions := make([]files.SourceVersion, 0, len(versionFiles))
+
+	for _, versionFile := range versionFiles {
+		contents, err := git.VersionAtBranch(ctx, o.dir, o.baseBranch, versionFile)
+		if err != nil {
+			return "", fmt.Errorf("error getting version at branch: %w", err)
+		}
+
+		was, err := files.GetVersionFromString(
+			versionFile,
+			contents,
+			files.ReadOptions{Anchors: o.anchors[versionFile]},
+		)
+		if err != nil {
+			return "", fmt.Errorf("error parsing the version from string: %w", err)
+		}
+
+		o.logger.Debug(fmt.Sprintf("file %s has version %s on branch %s", versionFile, was, o.baseBranch))
+
+		versions = append(versions, files.SourceVersion{Source: versionFile, Version: was})
+	}
+
+	//nolint:wrapcheck
+	return files.CommonVersion(versions)
+}
+
+// checkRange checks the now version is in the version range, when one is set.
+func (o options) checkRange(result *CheckResult) error {
+	result.Range = o.combinedRange()
+	if result.Range == "" {
+		return nil
+	}
+
+	satisfied, err := version.Satisfies(result.Now, result.Range)
+	if err != nil {
+		return fmt.Errorf("error checking version range: %w", err)
+	}
+
+	result.Satisfies = satisfied
+
+	if !satisfied {
+		return fmt.Errorf("%w: %s is not in %s", ErrConstraintNotSatisfied, result.Now, result.Range)
+	}
+
+	return nil
+}
+
+// checkBranchPolicy checks the bump type and new version are allowed by the
+// policy for the branch, when one applies.
+func (o options) checkBranchPolicy(result *CheckResult) error {
+	if result.Branch == "" {
+		return nil
+	}
+
+	pattern, policy, found := PolicyForBranch(o.policies, result.Branch)
+	if !found {
+		return nil
+	}
+
+	o.logger.Debug(fmt.Sprintf("branch %s matches branch policy %s", result.Branch, pattern))
+
+	result.Policy = pattern
+
+	if len(policy.Bumps) > 0 && !slices.Contains(policy.Bumps, result.BumpType) {
+		return fmt.Errorf(
+			"%w: rule %s for branch %s allows %s bumps, not %s",
+			ErrRejectedByBranchPolicy,
+			pattern,
+			result.Branch,
+			strings.Join(policy.Bumps, ", "),
+			result.BumpType,
+		)
+	}
+
+	if policy.Range == "" {
+		return nil
+	}
+
+	satisfied, err := version.Satisfies(result.Now, policy.Range)
+	if err != nil {
+		return fmt.Errorf("error checking range of branch policy %s: %w", pattern, err)
+	}
+
+	if !satisfied {
+		return fmt.Errorf(
+			"%w: rule %s for branch %s requires %s, %s is not in range",
+			ErrRejectedByBranchPolicy,
+			pattern,
+			result.Branch,
+			policy.Range,
+			result.Now,
+		)
+	}
+
+	return nil
+}
+
+// combinedRange combines the range and max version options into the single
+// range the now version must be in. The max version applies to every
+// alternative in the range, so ^1.2 || ^2.0 with a max of 2.1.0 means
+// ^1.2 <=2.1.0 || ^2.0 <=2.1.0.
+func (o options) combinedRange() string {
+	if o.maxVersion == "" {
+		return strings.TrimSpace(o.versionRange)
+	}
+
+	maxVersion := "<=" + o.maxVersion
+
+	if strings.TrimSpace(o.versionRange) == "" {
+		return maxVersion
+	}
+
+	sets := strings.Split(o.versionRange, "||")
+	for i, set := range sets {
+		sets[i] = strings.TrimSpace(set) + " " + maxVersion
+	}
+
+	return strings.Join(sets, " || ")
+}
@@ -0,0 +1,124 @@
+package vrsn_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tx3stn/vrsn/pkg/vrsn"
+)
+
+func TestCheck(t *testing.T) {
+	testCases := map[string]struct {
+		opts          []vrsn.Option
+		expected      vrsn.CheckResult
+		expectedError error
+	}{
+		"AcceptsValidBump": {
+			opts: []vrsn.Option{vrsn.WithWas("1.2.3"), vrsn.WithNow("1.3.0")},
+			expected: vrsn.CheckResult{
+				BumpType: vrsn.Minor,
+				Now:      "1.3.0",
+				Was:      "1.2.3",
+			},
+			expectedError: nil,
+		},
+		"RejectsInvalidBump": {
+			opts: []vrsn.Option{vrsn.WithWas("1.2.3"), vrsn.WithNow("1.2.5")},
+			expected: vrsn.CheckResult{
+				Now: "1.2.5",
+				Was: "1.2.3",
+			},
+			expectedError: vrsn.ErrInvalidBump,
+		},
+		"AcceptsVersionInCombinedRange": {
+			opts: []vrsn.Option{
+				vrsn.WithWas("1.2.3"),
+				vrsn.WithNow("1.3.0"),
+				vrsn.WithRange("^1.2"),
+				vrsn.WithMaxVersion("1.3.0"),
+			},
+			expected: vrsn.CheckResult{
+				BumpType:  vrsn.Minor,
+				Now:       "1.3.0",
+				Range:     "^1.2 <=1.3.0",
+				Satisfies: true,
+				Was:       "1.2.3",
+			},
+			expectedError: nil,
+		},
+		"RejectsVersionAboveMaxVersion": {
+			opts: []vrsn.Option{
+				vrsn.WithWas("1.2.3"),
+				vrsn.WithNow("2.0.0"),
+				vrsn.WithMaxVersion("1.x"),
+			},
+			expected: vrsn.CheckResult{
+				BumpType:  vrsn.Major,
+				Now:       "2.0.0",
+				Range:     "<=1.x",
+				Satisfies: false,
+				Was:       "1.2.3",
+			},
+			expectedError: vrsn.ErrConstraintNotSatisfied,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// Running outside of a git repo means there is no branch, so no
+			// branch policy applies.
+			opts := append([]vrsn.Option{vrsn.WithDir(t.TempDir())}, tc.opts...)
+
+			result, err := vrsn.Check(t.Context(), opts...)
+			require.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestPolicyForBranch(t *testing.T) {
+	policies := map[string]vrsn.BranchPolicy{
+		"release/*":   {Bumps: []string{"patch", "minor"}},
+		"release/1.x": {Bumps: []string{"patch"}, Range: "1.x"},
+	}
+
+	testCases := map[string]struct {
+		branch          string
+		expectedPattern string
+		expectedPolicy  vrsn.BranchPolicy
+		expectedFound   bool
+	}{
+		"MostSpecificPatternWins": {
+			branch:          "release/1.x",
+			expectedPattern: "release/1.x",
+			expectedPolicy:  vrsn.BranchPolicy{Bumps: []string{"patch"}, Range: "1.x"},
+			expectedFound:   true,
+		},
+		"MatchesGlobPattern": {
+			branch:          "release/2.x",
+			expectedPattern: "release/*",
+			expectedPolicy:  vrsn.BranchPolicy{Bumps: []string{"patch", "minor"}, Range: ""},
+			expectedFound:   true,
+		},
+		"ReturnsNotFoundForUnmatchedBranch": {
+			branch:          "main",
+			expectedPattern: "",
+			expectedPolicy:  vrsn.BranchPolicy{Bumps: nil, Range: ""},
+			expectedFound:   false,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			pattern, policy, found := vrsn.PolicyForBranch(policies, tc.branch)
+			assert.Equal(t, tc.expectedPattern, pattern)
+			assert.Equal(t, tc.expectedPolicy, policy)
+			assert.Equal(t, tc.expectedFound, found)
+		})
+	}
+}
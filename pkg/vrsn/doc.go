@@ -0,0 +1,16 @@
+// Package vrsn is the public Go API for vrsn, for tools that want to read,
+// write and check versions the same way the vrsn CLI does, without running
+// the CLI or depending on its flags.
+//
+// Functions that touch files or git take a context and functional options,
+// e.g. reading the version from the version file in a directory:
+//
+//	current, err := vrsn.ReadVersions(ctx, vrsn.WithDir("path/to/repo"))
+//
+// or checking the version has been bumped from the version on main:
+//
+//	result, err := vrsn.Check(ctx, vrsn.WithDir("path/to/repo"), vrsn.WithBaseBranch("main"))
+//
+// Errors can be matched with errors.Is against the exported errors of the
+// package.
+package vrsn
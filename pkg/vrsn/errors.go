@@ -0,0 +1,65 @@
+package vrsn
+
+import (
+	"strconv"
+
+	"github.com/tx3stn/vrsn/internal/files"
+	"github.com/tx3stn/vrsn/internal/version"
+)
+
+// Error is the error type.
+type Error uint
+
+const (
+	// ErrOnBaseBranch is the error when Check is run on the base branch with
+	// no previous version, so there is nothing to compare.
+	ErrOnBaseBranch Error = iota + 1
+	// ErrRejectedByBranchPolicy is the error when Check finds a valid version
+	// bump that the policy for the current branch doesn't allow.
+	ErrRejectedByBranchPolicy
+)
+
+// Errors returned from the internal packages, exported so they can be matched
+// with errors.Is.
+const (
+	// ErrConstraintNotSatisfied is the error when a version is outside of the
+	// version range.
+	ErrConstraintNotSatisfied = version.ErrConstraintNotSatisfied
+	// ErrInvalidBump is the error when the change between two versions isn't
+	// a valid semantic version increment.
+	ErrInvalidBump = version.ErrInvalidBump
+	// ErrInvalidIncrementType is the error when the increment type isn't one
+	// of Patch, Minor or Major.
+	ErrInvalidIncrementType = version.ErrInvalidIncrementType
+	// ErrMultipleVersionFiles is the error when no files are set and the
+	// directory contains more than one version file.
+	ErrMultipleVersionFiles = files.ErrMultipleVersionFiles
+	// ErrNoVersionFiles is the error when no files are set and the directory
+	// contains no version files.
+	ErrNoVersionFiles = files.ErrNoVersionFilesInDir
+	// ErrVersionNotBumped is the error when two versions are the same.
+	ErrVersionNotBumped = version.ErrVersionNotBumped
+	// ErrVersionsDoNotMatch is the error when the version files don't all
+	// contain the same version.
+	ErrVersionsDoNotMatch = files.ErrVersionsDoNotMatch
+)
+
+// Error returns the error string for the error enum.
+func (e Error) Error() string {
+	switch e {
+	case ErrOnBaseBranch:
+		return "on base branch with no previous version, nothing to compare"
+
+	case ErrRejectedByBranchPolicy:
+		return "version change rejected by branch policy"
+
+	default:
+		return "unknown error"
+	}
+}
+
+// Code returns the stable code for the error enum used in machine readable
+// output.
+func (e Error) Code() string {
+	return "vrsn." + strconv.FormatUint(uint64(e), 10)
+}
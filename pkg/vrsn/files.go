@@ -0,0 +1,121 @@
+package vrsn
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tx3stn/vrsn/internal/buildnumber"
+	"github.com/tx3stn/vrsn/internal/files"
+	"github.com/tx3stn/vrsn/internal/logger"
+	"github.com/tx3stn/vrsn/internal/version"
+)
+
+// FindVersionFiles returns the supported version files in the directory set
+// with WithDir.
+func FindVersionFiles(ctx context.Context, opts ...Option) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("error finding version files: %w", err)
+	}
+
+	o := newOptions(opts)
+
+	//nolint:wrapcheck
+	return files.GetVersionFilesInDirectory(o.dir)
+}
+
+// ReadVersion reads the version from the version file, relative to the
+// directory set with WithDir.
+func ReadVersion(ctx context.Context, file string, opts ...Option) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", fmt.Errorf("error reading version from %s: %w", file, err)
+	}
+
+	o := newOptions(opts)
+
+	//nolint:wrapcheck
+	return files.GetVersionFromFile(o.dir, file, files.ReadOptions{Anchors: o.anchors[file]})
+}
+
+// ReadVersions reads the version from each of the files set with WithFiles,
+// or the single version file found in the directory when none are set, and
+// returns the version they all contain. ErrVersionsDoNotMatch is returned if
+// the files contain different versions.
+func ReadVersions(ctx context.Context, opts ...Option) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", fmt.Errorf("error reading versions: %w", err)
+	}
+
+	o := newOptions(opts)
+
+	versionFiles, err := o.versionFiles()
+	if err != nil {
+		return "", err
+	}
+
+	//nolint:wrapcheck
+	return files.GetVersionsFromFiles(o.dir, versionFiles, o.anchors, o.debugLogger())
+}
+
+// WriteVersion writes the new version to the version file, relative to the
+// directory set with WithDir, changing only the version.
+func WriteVersion(ctx context.Context, file string, newVersion string, opts ...Option) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("error writing version to %s: %w", file, err)
+	}
+
+	o := newOptions(opts)
+	writeOpts := files.WriteOptions{NewVersion: newVersion, Anchors: o.anchors[file]}
+
+	if o.androidVersionCode || o.appleBuildNumber {
+		code, err := version.NumericCode(newVersion, buildnumber.DefaultDigits, buildnumber.DefaultDigits)
+		if err != nil {
+			return fmt.Errorf("error deriving version code: %w", err)
+		}
+
+		if o.androidVersionCode {
+			writeOpts.AndroidVersionCode = files.FixedBuildNumber(code)
+		}
+
+		if o.appleBuildNumber {
+			writeOpts.AppleBuildNumber = files.FixedBuildNumber(code)
+		}
+	}
+
+	//nolint:wrapcheck
+	return files.WriteVersionToFile(o.dir, file, writeOpts)
+}
+
+// versionFiles returns the files set with WithFiles, or the single version
+// file found in the directory when none are set.
+func (o options) versionFiles() ([]string, error) {
+	if len(o.files) > 0 {
+		return o.files, nil
+	}
+
+	found, err := files.GetVersionFilesInDirectory(o.dir)
+	if err != nil {
+		return nil, fmt.Errorf("error finding version file: %w", err)
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, ErrNoVersionFiles
+
+	case 1:
+		o.logger.Debug("found version file", "file", found[0])
+
+		return found, nil
+
+	default:
+		return nil, fmt.Errorf("%w: %v", ErrMultipleVersionFiles, found)
+	}
+}
+
+// debugLogger adapts the slog logger for the internal packages, which only
+// write debug logs.
+func (o options) debugLogger() logger.Logger {
+	log := logger.NewBasic(false, o.logger)
+	log.Quiet = true
+
+	return log
+}
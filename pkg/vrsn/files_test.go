@@ -0,0 +1,105 @@
+package vrsn_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tx3stn/vrsn/pkg/vrsn"
+)
+
+func TestReadVersions(t *testing.T) {
+	testCases := map[string]struct {
+		files         map[string]string
+		opts          []vrsn.Option
+		expected      string
+		expectedError error
+	}{
+		"ReadsSingleVersionFileInDir": {
+			files:         map[string]string{"VERSION": "1.2.3\n"},
+			opts:          nil,
+			expected:      "1.2.3",
+			expectedError: nil,
+		},
+		"ReadsCommonVersionOfFiles": {
+			files: map[string]string{
+				"VERSION":      "1.2.3\n",
+				"package.json": `{"version":"1.2.3"}`,
+			},
+			opts:          []vrsn.Option{vrsn.WithFiles("VERSION", "package.json")},
+			expected:      "1.2.3",
+			expectedError: nil,
+		},
+		"ErrorsWhenFilesDoNotMatch": {
+			files: map[string]string{
+				"VERSION":      "1.2.3\n",
+				"package.json": `{"version":"1.2.4"}`,
+			},
+			opts:          []vrsn.Option{vrsn.WithFiles("VERSION", "package.json")},
+			expected:      "",
+			expectedError: vrsn.ErrVersionsDoNotMatch,
+		},
+		"ErrorsWhenMultipleVersionFilesInDir": {
+			files: map[string]string{
+				"VERSION":      "1.2.3\n",
+				"package.json": `{"version":"1.2.3"}`,
+			},
+			opts:          nil,
+			expected:      "",
+			expectedError: vrsn.ErrMultipleVersionFiles,
+		},
+		"ErrorsWhenNoVersionFilesInDir": {
+			files:         map[string]string{},
+			opts:          nil,
+			expected:      "",
+			expectedError: vrsn.ErrNoVersionFiles,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+
+			for file, contents := range tc.files {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, file), []byte(contents), 0o600))
+			}
+
+			opts := append([]vrsn.Option{vrsn.WithDir(dir)}, tc.opts...)
+
+			version, err := vrsn.ReadVersions(t.Context(), opts...)
+			require.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, tc.expected, version)
+		})
+	}
+}
+
+func TestWriteVersion(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	manifest := `<manifest android:versionCode="10203" android:versionName="1.2.3">` + "\n"
+
+	manifestPath := filepath.Join(dir, "AndroidManifest.xml")
+	require.NoError(t, os.WriteFile(manifestPath, []byte(manifest), 0o600))
+
+	err := vrsn.WriteVersion(
+		t.Context(),
+		"AndroidManifest.xml",
+		"1.3.0",
+		vrsn.WithDir(dir),
+		vrsn.WithAndroidVersionCode(true),
+	)
+	require.NoError(t, err)
+
+	version, err := vrsn.ReadVersion(t.Context(), "AndroidManifest.xml", vrsn.WithDir(dir))
+	require.NoError(t, err)
+	assert.Equal(t, "1.3.0", version)
+
+	contents, err := os.ReadFile(manifestPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(contents), `android:versionCode="10300"`)
+}
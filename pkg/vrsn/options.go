@@ -0,0 +1,141 @@
+package vrsn
+
+import "log/slog"
+
+type (
+	// Option configures the functions of the package.
+	Option func(*options)
+
+	options struct {
+		anchors            map[string][]string
+		androidVersionCode bool
+		appleBuildNumber   bool
+		baseBranch         string
+		dir                string
+		files              []string
+		logger             *slog.Logger
+		maxVersion         string
+		now                string
+		policies           map[string]BranchPolicy
+		versionRange       string
+		was                string
+	}
+)
+
+// defaultBaseBranch is the branch Check compares against when WithBaseBranch
+// isn't used, matching the default of the CLI --base-branch flag.
+const defaultBaseBranch = "main"
+
+func newOptions(opts []Option) options {
+	o := options{
+		baseBranch: defaultBaseBranch,
+		dir:        ".",
+		logger:     slog.Default(),
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// WithAnchors sets the text preceding each occurrence of the version in files
+// that repeat it, keyed by the file as passed to WithFiles. Anchors replace
+// the usual version matching for the file.
+func WithAnchors(anchors map[string][]string) Option {
+	return func(o *options) {
+		o.anchors = anchors
+	}
+}
+
+// WithAndroidVersionCode also writes android:versionCode in AndroidManifest
+// files, derived from the new version with the formula build number strategy
+// and its default digits, so 1.2.3 is 10203.
+func WithAndroidVersionCode(enabled bool) Option {
+	return func(o *options) {
+		o.androidVersionCode = enabled
+	}
+}
+
+// WithAppleBuildNumber also writes CFBundleVersion in Info.plist files and
+// CURRENT_PROJECT_VERSION in Xcode projects, derived from the new version with
+// the formula build number strategy and its default digits, so 1.2.3 is 10203.
+func WithAppleBuildNumber(enabled bool) Option {
+	return func(o *options) {
+		o.appleBuildNumber = enabled
+	}
+}
+
+// WithBaseBranch sets the branch Check reads the previous version from,
+// defaults to main.
+func WithBaseBranch(branch string) Option {
+	return func(o *options) {
+		o.baseBranch = branch
+	}
+}
+
+// WithBranchPolicies sets the policies Check applies to the version change,
+// keyed by the branch pattern they apply to, see PolicyForBranch.
+func WithBranchPolicies(policies map[string]BranchPolicy) Option {
+	return func(o *options) {
+		o.policies = policies
+	}
+}
+
+// WithDir sets the directory version files and git commands are relative to,
+// defaults to the current working directory.
+func WithDir(dir string) Option {
+	return func(o *options) {
+		o.dir = dir
+	}
+}
+
+// WithFiles sets the version files to operate on, all of which must contain
+// the same version. When no files are set the directory is searched for a
+// single supported version file.
+func WithFiles(files ...string) Option {
+	return func(o *options) {
+		o.files = files
+	}
+}
+
+// WithLogger sets the logger debug logs are written to, defaults to
+// slog.Default.
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithMaxVersion sets the highest version Check accepts, as a version or a
+// partial version like 1.x.
+func WithMaxVersion(maxVersion string) Option {
+	return func(o *options) {
+		o.maxVersion = maxVersion
+	}
+}
+
+// WithNow sets the new version for Check, instead of reading it from the
+// version files.
+func WithNow(now string) Option {
+	return func(o *options) {
+		o.now = now
+	}
+}
+
+// WithRange sets the version range the new version must be in for Check,
+// e.g. ^1.2 || ~2.0.
+func WithRange(versionRange string) Option {
+	return func(o *options) {
+		o.versionRange = versionRange
+	}
+}
+
+// WithWas sets the previous version for Check, instead of reading it from the
+// version files on the base branch.
+func WithWas(was string) Option {
+	return func(o *options) {
+		o.was = was
+	}
+}
@@ -0,0 +1,69 @@
+package vrsn
+
+import (
+	"fmt"
+
+	"github.com/tx3stn/vrsn/internal/version"
+)
+
+// SemVer is a parsed semantic version.
+type SemVer = version.SemVer
+
+// Increment types of a version bump, as returned by BumpType.
+const (
+	Patch = "patch"
+	Minor = "minor"
+	Major = "major"
+)
+
+// Parse parses the version into its parts, erroring if it isn't a valid
+// semantic version.
+func Parse(input string) (SemVer, error) {
+	//nolint:wrapcheck
+	return version.Parse(input)
+}
+
+// BumpType returns the type of increment, Patch, Minor or Major, from the was
+// version to the now version. An error is returned if the change isn't a
+// valid semantic version increment, e.g. ErrVersionNotBumped when they are
+// the same.
+func BumpType(was string, now string) (string, error) {
+	//nolint:wrapcheck
+	return version.BumpType(was, now)
+}
+
+// Next returns the version after the current version for the increment type,
+// Patch, Minor or Major.
+func Next(current string, increment string) (string, error) {
+	options, err := version.GetBumpOptions(current)
+	if err != nil {
+		return "", fmt.Errorf("error getting bump options: %w", err)
+	}
+
+	switch increment {
+	case Patch:
+		return options.Patch, nil
+
+	case Minor:
+		return options.Minor, nil
+
+	case Major:
+		return options.Major, nil
+
+	default:
+		return "", fmt.Errorf("%w: %s", ErrInvalidIncrementType, increment)
+	}
+}
+
+// Satisfies reports whether the version is in the version range, e.g.
+// ^1.2 || ~2.0.
+func Satisfies(input string, versionRange string) (bool, error) {
+	//nolint:wrapcheck
+	return version.Satisfies(input, versionRange)
+}
+
+// Sort sorts the versions from lowest to highest.
+func Sort(versions []string) ([]string, error) {
+	//nolint:wrapcheck
+	return version.Sort(versions)
+}
@@ -0,0 +1,91 @@
+package vrsn_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tx3stn/vrsn/pkg/vrsn"
+)
+
+func TestNext(t *testing.T) {
+	testCases := map[string]struct {
+		current       string
+		increment     string
+		expected      string
+		expectedError error
+	}{
+		"BumpsPatch": {
+			current:       "1.2.3",
+			increment:     vrsn.Patch,
+			expected:      "1.2.4",
+			expectedError: nil,
+		},
+		"BumpsMinor": {
+			current:       "v1.2.3",
+			increment:     vrsn.Minor,
+			expected:      "v1.3.0",
+			expectedError: nil,
+		},
+		"BumpsMajor": {
+			current:       "1.2.3",
+			increment:     vrsn.Major,
+			expected:      "2.0.0",
+			expectedError: nil,
+		},
+		"ErrorsForUnknownIncrement": {
+			current:       "1.2.3",
+			increment:     "huge",
+			expected:      "",
+			expectedError: vrsn.ErrInvalidIncrementType,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			next, err := vrsn.Next(tc.current, tc.increment)
+			require.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, tc.expected, next)
+		})
+	}
+}
+
+func TestBumpType(t *testing.T) {
+	testCases := map[string]struct {
+		was           string
+		now           string
+		expected      string
+		expectedError error
+	}{
+		"ReturnsMinor": {
+			was:           "1.2.3",
+			now:           "1.3.0",
+			expected:      vrsn.Minor,
+			expectedError: nil,
+		},
+		"ErrorsWhenNotBumped": {
+			was:           "1.2.3",
+			now:           "1.2.3",
+			expected:      "",
+			expectedError: vrsn.ErrVersionNotBumped,
+		},
+		"ErrorsForInvalidBump": {
+			was:           "1.2.3",
+			now:           "1.4.0",
+			expected:      "",
+			expectedError: vrsn.ErrInvalidBump,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			bumpType, err := vrsn.BumpType(tc.was, tc.now)
+			require.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, tc.expected, bumpType)
+		})
+	}
+}